@@ -14,6 +14,7 @@ import (
 	"github.com/viant/datly/shared"
 	"github.com/viant/datly/template/sanitize"
 	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/sequence"
 	"github.com/viant/sqlx/metadata/ast/query"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/format"
@@ -64,6 +65,8 @@ type (
 		fileName       string
 		viewType       view.Mode
 		expandedTable  *Table
+		sequences      []*sequence.Config
+		tags           []string
 	}
 
 	templateMetaConfig struct {
@@ -931,8 +934,8 @@ func (s *Builder) prepareRuleIfNeeded(SQL []byte) (string, error) {
 	}
 
 	switch strings.ToLower(s.options.PrepareRule) {
-	case PreparePost:
-		return s.preparePostRule(context.Background(), SQL)
+	case PreparePost, PreparePut, PreparePatch, PrepareDelete, PrepareUpsert:
+		return s.prepareRule(context.Background(), strings.ToLower(s.options.PrepareRule), SQL)
 	default:
 		return "", fmt.Errorf("unsupported prepare rule type")
 	}
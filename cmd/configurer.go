@@ -445,6 +445,30 @@ func inheritFromTarget(target node.Node, view *viewConfig, tableNameComment stri
 	view.ensureInnerAlias(tableName)
 	view.ensureFileName(tableName)
 	view.parseComment(tableNameComment)
+	view.addSequence(tableName)
+	view.addTags()
+}
+
+//addSequence moves ID allocation strategy declared by the statement target hint to the view sequences
+func (c *viewConfig) addSequence(tableName string) {
+	viewConfig := &c.expandedTable.ViewConfig
+	if viewConfig.Sequence == nil {
+		return
+	}
+
+	if viewConfig.Sequence.Table == "" {
+		viewConfig.Sequence.Table = tableName
+	}
+
+	c.sequences = append(c.sequences, viewConfig.Sequence)
+	viewConfig.Sequence = nil
+}
+
+//addTags moves cache tags declared by the statement target hint to the view tags
+func (c *viewConfig) addTags() {
+	viewConfig := &c.expandedTable.ViewConfig
+	c.tags = append(c.tags, viewConfig.Tags...)
+	viewConfig.Tags = nil
 }
 
 func (c *viewConfig) parseComment(comment string) {
@@ -30,6 +30,8 @@ type (
 		pkIndex      map[string]sink.Key
 		table        string
 		config       *viewConfig
+		method       string
+		conflictKeys []string
 	}
 
 	typeMeta struct {
@@ -55,13 +57,14 @@ type (
 		typeDef   *insertData
 		paramName string
 		isMulti   bool
+		method    string
 
 		parent    *insertStmtBuilder
 		wroteHint *bool
 	}
 )
 
-func (s *Builder) preparePostRule(ctx context.Context, sourceSQL []byte) (string, error) {
+func (s *Builder) prepareRule(ctx context.Context, method string, sourceSQL []byte) (string, error) {
 	hint, SQL := s.extractRouteSettings(sourceSQL)
 
 	routeOption := &option.RouteConfig{}
@@ -88,7 +91,7 @@ func (s *Builder) preparePostRule(ctx context.Context, sourceSQL []byte) (string
 		return "", err
 	}
 
-	template, err := s.detectTypeAndBuildPostSQL(ctx, config, db, routeOption)
+	template, err := s.detectTypeAndBuildSQL(ctx, method, config, db, routeOption)
 	if err != nil {
 		return "", err
 	}
@@ -106,17 +109,17 @@ func (s *Builder) extractRouteSettings(sourceSQL []byte) (string, string) {
 	return hint, SQL
 }
 
-func (s *Builder) detectTypeAndBuildPostSQL(ctx context.Context, aViewConfig *viewConfig, db *sql.DB, routeOption *option.RouteConfig) (string, error) {
+func (s *Builder) detectTypeAndBuildSQL(ctx context.Context, method string, aViewConfig *viewConfig, db *sql.DB, routeOption *option.RouteConfig) (string, error) {
 	tableName := aViewConfig.expandedTable.Name
-	parameterType, err := s.detectInputType(ctx, db, tableName, aViewConfig, "")
+	parameterType, err := s.detectInputType(ctx, db, tableName, aViewConfig, "", method)
 	if err != nil {
 		return "", err
 	}
 
-	return s.buildInsertSQL(parameterType, aViewConfig, routeOption)
+	return s.buildRuleSQL(parameterType, aViewConfig, routeOption)
 }
 
-func (s *Builder) uploadGoType(name string, rType reflect.Type) error {
+func (s *Builder) uploadGoType(name string, rType reflect.Type, method string) error {
 
 	fileContent := xreflect.GenerateStruct(name, rType)
 	if _, err := s.uploadGo(folderSQL, name, fileContent, false); err != nil {
@@ -126,7 +129,7 @@ func (s *Builder) uploadGoType(name string, rType reflect.Type) error {
 	sampleValue := getStruct(rType)
 	sample := sampleValue.Interface()
 	if data, err := json.Marshal(sample); err == nil {
-		s.uploadFile(folderSQL, name+"Post", string(data), false, ".json")
+		s.uploadFile(folderSQL, name+strings.Title(method), string(data), false, ".json")
 	}
 
 	return nil
@@ -185,7 +188,7 @@ func getStruct(rType reflect.Type) reflect.Value {
 	return sampleValue
 }
 
-func (s *Builder) detectInputType(ctx context.Context, db *sql.DB, tableName string, config *viewConfig, parentTable string) (*insertData, error) {
+func (s *Builder) detectInputType(ctx context.Context, db *sql.DB, tableName string, config *viewConfig, parentTable string, method string) (*insertData, error) {
 	columns, err := s.readSinkColumns(ctx, db, tableName)
 	if err != nil {
 		return nil, err
@@ -201,7 +204,14 @@ func (s *Builder) detectInputType(ctx context.Context, db *sql.DB, tableName str
 		return nil, err
 	}
 
-	return s.buildPostInputParameterType(columns, foreignKeys, primaryKeys, config, db, tableName, parentTable)
+	data, err := s.buildInputParameterType(columns, foreignKeys, primaryKeys, config, db, tableName, parentTable, method)
+	//PUT replaces relation records with upsert
+	if err != nil || (method != PrepareUpsert && (method != PreparePut || parentTable == "")) {
+		return data, err
+	}
+
+	data.conflictKeys, err = s.readConflictKeys(ctx, db, tableName, primaryKeys)
+	return data, err
 }
 
 func (s *Builder) readForeignKeys(ctx context.Context, db *sql.DB, tableName string) ([]sink.Key, error) {
@@ -234,7 +244,7 @@ func (s *Builder) readPrimaryKeys(ctx context.Context, db *sql.DB, tableName str
 	return s.filterKeys(keys, tableName), nil
 }
 
-func (s *Builder) buildPostInputParameterType(columns []sink.Column, foreignKeys []sink.Key, primaryKeys []sink.Key, config *viewConfig, db *sql.DB, table, parentTable string) (*insertData, error) {
+func (s *Builder) buildInputParameterType(columns []sink.Column, foreignKeys []sink.Key, primaryKeys []sink.Key, config *viewConfig, db *sql.DB, table, parentTable string, method string) (*insertData, error) {
 	fkIndex := s.indexKeys(foreignKeys)
 	pkIndex := s.indexKeys(primaryKeys)
 
@@ -281,6 +291,10 @@ func (s *Builder) buildPostInputParameterType(columns []sink.Column, foreignKeys
 			return nil, err
 		}
 
+		if method == PrepareDelete && parentTable == "" && !meta.primaryKey {
+			continue
+		}
+
 		//if s.shouldFilterColumnByMeta(parentTable, fkIndex, meta) {
 		//	continue
 		//}
@@ -338,6 +352,10 @@ func (s *Builder) buildPostInputParameterType(columns []sink.Column, foreignKeys
 	holderName := ""
 	paramName := name
 	actualFields := definition.Fields
+	if method == PreparePatch {
+		definition.Fields = append(append([]*view.Field{}, actualFields...), s.buildPresenceField(paramName, actualFields))
+	}
+
 	if !config.outputConfig.IsBasic() {
 		holderName = config.outputConfig.Field()
 		definition.Name = holderName
@@ -353,12 +371,16 @@ func (s *Builder) buildPostInputParameterType(columns []sink.Column, foreignKeys
 		definition.Cardinality = ""
 	}
 
-	insertRelations, err := s.buildInsertRelations(config, db)
+	insertRelations, err := s.buildInsertRelations(config, db, method)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, relation := range insertRelations {
+		if method == PrepareDelete {
+			break
+		}
+
 		definition.Fields = append(definition.Fields, &view.Field{
 			Name:        relation.paramName,
 			Fields:      relation.typeDef.Fields,
@@ -379,6 +401,7 @@ func (s *Builder) buildPostInputParameterType(columns []sink.Column, foreignKeys
 		pkIndex:      pkIndex,
 		table:        table,
 		config:       config,
+		method:       method,
 	}, nil
 }
 
@@ -394,10 +417,10 @@ func (s *Builder) shouldFilterColumnByMeta(parentTable string, fkIndex map[strin
 	return true
 }
 
-func (s *Builder) buildInsertRelations(config *viewConfig, db *sql.DB) ([]*insertData, error) {
+func (s *Builder) buildInsertRelations(config *viewConfig, db *sql.DB, method string) ([]*insertData, error) {
 	var relations []*insertData
 	for _, relation := range config.relations {
-		relationConfig, err := s.detectInputType(context.TODO(), db, relation.expandedTable.Name, relation, config.expandedTable.Name)
+		relationConfig, err := s.detectInputType(context.TODO(), db, relation.expandedTable.Name, relation, config.expandedTable.Name, method)
 		if err != nil {
 			return nil, err
 		}
@@ -464,7 +487,7 @@ func (s *Builder) buildFieldMeta(column sink.Column, pkIndex map[string]sink.Key
 		meta.fkKey = &fkKey
 	}
 
-	if _, ok := pkIndex[column.Name]; ok {
+	if _, ok := pkIndex[strings.ToLower(column.Name)]; ok {
 		meta.primaryKey = true
 	}
 
@@ -510,7 +533,7 @@ func (s *Builder) indexKeys(primaryKeys []sink.Key) map[string]sink.Key {
 	return pkIndex
 }
 
-func (s *Builder) buildInsertSQL(typeDef *insertData, config *viewConfig, routeOption *option.RouteConfig) (string, error) {
+func (s *Builder) buildRuleSQL(typeDef *insertData, config *viewConfig, routeOption *option.RouteConfig) (string, error) {
 	sb := &strings.Builder{}
 	typeName := typeDef.typeDef.Name
 
@@ -519,22 +542,33 @@ func (s *Builder) buildInsertSQL(typeDef *insertData, config *viewConfig, routeO
 		return "", err
 	}
 
-	if err = s.uploadGoType(typeName, paramType); err != nil {
+	if err = s.uploadGoType(typeName, paramType, typeDef.method); err != nil {
 		return "", err
 	}
 
-	if err = s.appendPostRouteOption(typeDef.paramName, routeOption, typeName, typeDef, sb); err != nil {
+	if err = s.appendRouteOption(typeDef.paramName, routeOption, typeName, typeDef, sb); err != nil {
 		return "", err
 	}
 
 	builder := newInsertStmtBuilder(sb, typeDef)
-	builder.appendAllocation(typeDef, "", typeDef.paramName)
+	switch typeDef.method {
+	case PreparePost:
+		builder.appendAllocation(typeDef, "", typeDef.paramName)
+	case PreparePut:
+		//new relation records are inserted, records being updated have their keys already set
+		for _, relation := range typeDef.relations {
+			builder.appendAllocation(relation, relation.paramName+"/", typeDef.paramName)
+		}
+	}
 
 	return builder.build("", true)
 }
 
-func (s *Builder) appendPostRouteOption(paramName string, routeOption *option.RouteConfig, typeName string, typeDef *insertData, sb *strings.Builder) error {
+func (s *Builder) appendRouteOption(paramName string, routeOption *option.RouteConfig, typeName string, typeDef *insertData, sb *strings.Builder) error {
 	requiredTypes := []string{"*" + typeDef.paramName}
+	if routeOption.Method == "" {
+		routeOption.Method = routeMethod(typeDef.method)
+	}
 
 	routeOption.RequestBody = &option.BodyConfig{
 		DataType: typeDef.bodyHolder,
@@ -610,6 +644,7 @@ func newInsertStmtBuilder(sb *strings.Builder, def *insertData) *insertStmtBuild
 		paramName: def.paramName,
 		wroteHint: boolPtr(false),
 		isMulti:   def.config.outputConfig.IsMany(),
+		method:    def.method,
 	}
 }
 
@@ -652,6 +687,49 @@ func (isb *insertStmtBuilder) build(parentRecord string, withUnsafe bool) (strin
 		}
 	}
 
+	if err := isb.appendStatement(indirectParent, name); err != nil {
+		return "", err
+	}
+
+	for _, rel := range isb.typeDef.relations {
+		if isb.method == PrepareDelete {
+			break
+		}
+
+		relBuilder := isb.newRelation(rel)
+		if err := relBuilder.appendReplaceDelete(name, !isb.isMulti && withUnsafe); err != nil {
+			return "", err
+		}
+
+		if _, err := relBuilder.build(name, !isb.isMulti && withUnsafe); err != nil {
+			return "", err
+		}
+	}
+
+	if isb.isMulti {
+		isb.writeString("\n#end")
+	}
+	return isb.sb.String(), nil
+}
+
+func (isb *insertStmtBuilder) appendStatement(parentRecord, name string) error {
+	switch isb.method {
+	case PreparePut, PreparePatch:
+		if isb.isReplaced() {
+			return isb.appendUpsert(parentRecord, name)
+		}
+
+		return isb.appendUpdate(parentRecord, name)
+	case PrepareDelete:
+		return isb.appendDelete(parentRecord, name)
+	case PrepareUpsert:
+		return isb.appendUpsert(parentRecord, name)
+	}
+
+	return isb.appendInsert(parentRecord, name)
+}
+
+func (isb *insertStmtBuilder) appendInsert(parentRecord, name string) error {
 	isb.writeString("\nINSERT INTO ")
 	isb.writeString(isb.typeDef.table)
 	isb.writeString(" (\n")
@@ -669,26 +747,15 @@ func (isb *insertStmtBuilder) build(parentRecord string, withUnsafe bool) (strin
 			isb.writeString(",\n")
 		}
 		isb.writeString("$")
-		isb.writeString(isb.accessParam(indirectParent, name, false))
+		isb.writeString(isb.accessParam(parentRecord, name, false))
 		isb.writeString(".")
 		isb.writeString(field.Name)
 		if err := isb.tryWriteParamHint(); err != nil {
-			return "", err
+			return err
 		}
 	}
 	isb.writeString("\n);\n")
-
-	for _, rel := range isb.typeDef.relations {
-		_, err := isb.newRelation(rel).build(name, !isb.isMulti && withUnsafe)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	if isb.isMulti {
-		isb.writeString("\n#end")
-	}
-	return isb.sb.String(), nil
+	return nil
 }
 
 func (isb *insertStmtBuilder) writeString(value string) {
@@ -703,6 +770,7 @@ func (isb *insertStmtBuilder) paramHint(typeDef *insertData) (string, error) {
 	target := typeDef.bodyHolder
 
 	paramConfig, err := json.Marshal(&option.ParameterConfig{
+		Kind:        string(view.KindRequestBody),
 		Target:      &target,
 		DataType:    typeDef.paramName,
 		Cardinality: typeDef.typeDef.Cardinality,
@@ -15,6 +15,8 @@ const (
 	PreparePost   = "post"
 	PreparePut    = "put"
 	PrepareDelete = "delete"
+	PreparePatch  = "patch"
+	PrepareUpsert = "upsert"
 
 	folderDev = "dev"
 	folderSQL = "dsql"
@@ -54,7 +56,7 @@ type (
 	}
 
 	Prepare struct {
-		PrepareRule string `short:"G" long:"generate" description:"prepare rule for patch|post|put|delete"`
+		PrepareRule string `short:"G" long:"generate" description:"prepare rule for patch|post|put|delete|upsert"`
 	}
 )
 
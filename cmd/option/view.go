@@ -1,6 +1,9 @@
 package option
 
-import "github.com/viant/datly/view"
+import (
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/sequence"
+)
 
 type ViewConfig struct {
 	Connector         string
@@ -11,4 +14,6 @@ type ViewConfig struct {
 	Auth              string
 	Selector          *view.Config
 	AllowNulls        *bool
+	Sequence          *sequence.Config //executor statement target table ID allocation strategy
+	Tags              []string         //cache tags, executor view purges caches tagged with its tags after commit
 }
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/viant/datly/view"
+	"strings"
+)
+
+const presenceFieldName = "Has"
+
+//buildPresenceField returns field tracking which of the fields were present in the request body
+func (s *Builder) buildPresenceField(typeName string, fields []*view.Field) *view.Field {
+	presenceFields := make([]*view.Field, 0, len(fields))
+	for _, field := range fields {
+		presenceFields = append(presenceFields, &view.Field{
+			Name:   field.Name,
+			Schema: &view.Schema{DataType: "bool"},
+		})
+	}
+
+	return &view.Field{
+		Name:   presenceFieldName,
+		Fields: presenceFields,
+		Tag:    fmt.Sprintf(`jsonIndex:"true" typeName:"%v%v" json:"-" sqlx:"-"`, typeName, presenceFieldName),
+		Ptr:    true,
+	}
+}
+
+func (isb *insertStmtBuilder) appendUpdate(parentRecord, name string) error {
+	primaryKeys, columns := isb.typeDef.meta.splitPrimaryKeys()
+	if len(primaryKeys) == 0 {
+		return fmt.Errorf("failed to generate %v rule, table %v has no primary key", isb.method, isb.typeDef.table)
+	}
+
+	if len(columns) == 0 && isb.method == PreparePut {
+		return fmt.Errorf("failed to generate %v rule, table %v has no columns to update", isb.method, isb.typeDef.table)
+	}
+
+	record := isb.accessParam(parentRecord, name, false)
+	isb.writeString("\nUPDATE ")
+	isb.writeString(isb.typeDef.table)
+	isb.writeString(" SET")
+
+	if isb.method == PreparePatch {
+		//primary key self assignment allows to append every present column with leading comma
+		isb.writeString(fmt.Sprintf("\n%v = %v", primaryKeys[0].columnName, primaryKeys[0].columnName))
+		for _, meta := range columns {
+			isb.writeString(fmt.Sprintf("\n#if($%v.%v.%v)\n, %v = $%v.%v", record, presenceFieldName, meta.fieldName, meta.columnName, record, meta.fieldName))
+			if err := isb.tryWriteParamHint(); err != nil {
+				return err
+			}
+			isb.writeString("\n#end")
+		}
+	} else {
+		for i, meta := range columns {
+			if i != 0 {
+				isb.writeString(",")
+			}
+			isb.writeString(fmt.Sprintf("\n%v = $%v.%v", meta.columnName, record, meta.fieldName))
+			if err := isb.tryWriteParamHint(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return isb.appendPrimaryKeyCriteria(primaryKeys, record)
+}
+
+func (isb *insertStmtBuilder) appendDelete(parentRecord, name string) error {
+	primaryKeys, _ := isb.typeDef.meta.splitPrimaryKeys()
+	if len(primaryKeys) == 0 {
+		return fmt.Errorf("failed to generate %v rule, table %v has no primary key", isb.method, isb.typeDef.table)
+	}
+
+	record := isb.accessParam(parentRecord, name, false)
+	criteria := make([]string, 0, len(primaryKeys))
+	for _, meta := range primaryKeys {
+		criteria = append(criteria, fmt.Sprintf("%v = $%v.%v", meta.columnName, record, meta.fieldName))
+	}
+
+	if err := isb.appendRelationsDelete(isb.typeDef, strings.Join(criteria, " AND ")); err != nil {
+		return err
+	}
+
+	isb.writeString("\nDELETE FROM ")
+	isb.writeString(isb.typeDef.table)
+	return isb.appendPrimaryKeyCriteria(primaryKeys, record)
+}
+
+//isReplaced returns true if PUT replaces one-to-many relation records, missing records are inserted and records
+//that are not part of the request body are deleted
+func (isb *insertStmtBuilder) isReplaced() bool {
+	return isb.method == PreparePut && isb.parent != nil && isb.isMulti
+}
+
+//appendReplaceDelete deletes replaced relation records, and their relations, that are not part of the parent request body
+func (isb *insertStmtBuilder) appendReplaceDelete(parentRecord string, withUnsafe bool) error {
+	if !isb.isReplaced() {
+		return nil
+	}
+
+	primaryKeys, _ := isb.typeDef.meta.splitPrimaryKeys()
+	if len(primaryKeys) == 0 {
+		return fmt.Errorf("failed to generate %v rule, table %v has no primary key", isb.method, isb.typeDef.table)
+	}
+
+	fkMeta, ok := isb.typeDef.parentKey(isb.parent.typeDef.table)
+	if !ok {
+		return fmt.Errorf("failed to generate %v rule, not found %v foreign key referencing %v", isb.method, isb.typeDef.table, isb.parent.typeDef.table)
+	}
+
+	refMeta, ok := isb.parent.typeDef.meta.metaByColName(fkMeta.fkKey.ReferenceColumn)
+	if !ok {
+		return fmt.Errorf("failed to generate %v rule, %v column %v is not part of %v input", isb.method, isb.parent.typeDef.table, fkMeta.fkKey.ReferenceColumn, isb.parent.typeDef.table)
+	}
+
+	recName := "rec" + isb.paramName
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%v = $%v", fkMeta.columnName, isb.accessParam(parentRecord, refMeta.fieldName, withUnsafe)))
+	sb.WriteString(fmt.Sprintf("\n#foreach($%v in $%v)", recName, isb.accessParam(parentRecord, isb.paramName, withUnsafe)))
+	if len(primaryKeys) == 1 {
+		sb.WriteString(fmt.Sprintf("\nAND %v <> $%v.%v", primaryKeys[0].columnName, recName, primaryKeys[0].fieldName))
+	} else {
+		keys := make([]string, 0, len(primaryKeys))
+		for _, meta := range primaryKeys {
+			keys = append(keys, fmt.Sprintf("%v = $%v.%v", meta.columnName, recName, meta.fieldName))
+		}
+		sb.WriteString(fmt.Sprintf("\nAND NOT (%v)", strings.Join(keys, " AND ")))
+	}
+	sb.WriteString("\n#end")
+
+	criteria := sb.String()
+	if err := isb.appendRelationsDelete(isb.typeDef, criteria); err != nil {
+		return err
+	}
+
+	isb.writeString(fmt.Sprintf("\nDELETE FROM %v WHERE %v;\n", isb.typeDef.table, criteria))
+	return nil
+}
+
+//appendRelationsDelete deletes relations records, starting from the most nested ones, referencing parent records matching criteria
+func (isb *insertStmtBuilder) appendRelationsDelete(parent *insertData, criteria string) error {
+	for _, rel := range parent.relations {
+		fkMeta, ok := rel.parentKey(parent.table)
+		if !ok {
+			return fmt.Errorf("failed to generate %v rule, not found %v foreign key referencing %v", isb.method, rel.table, parent.table)
+		}
+
+		relCriteria := fmt.Sprintf("%v IN (SELECT %v FROM %v WHERE %v)", fkMeta.columnName, fkMeta.fkKey.ReferenceColumn, parent.table, criteria)
+		if err := isb.appendRelationsDelete(rel, relCriteria); err != nil {
+			return err
+		}
+
+		isb.writeString(fmt.Sprintf("\nDELETE FROM %v WHERE %v;\n", rel.table, relCriteria))
+	}
+
+	return nil
+}
+
+func (isb *insertStmtBuilder) appendPrimaryKeyCriteria(primaryKeys []*fieldMeta, record string) error {
+	isb.writeString("\nWHERE ")
+	for i, meta := range primaryKeys {
+		if i != 0 {
+			isb.writeString(" AND ")
+		}
+		isb.writeString(fmt.Sprintf("%v = $%v.%v", meta.columnName, record, meta.fieldName))
+		if err := isb.tryWriteParamHint(); err != nil {
+			return err
+		}
+	}
+
+	isb.writeString(";\n")
+	return nil
+}
+
+//parentKey returns foreign key column referencing parent table
+func (d *insertData) parentKey(parentTable string) (*fieldMeta, bool) {
+	for _, meta := range d.meta.metas {
+		if meta.fkKey != nil && meta.fkKey.ReferenceTable == parentTable {
+			return meta, true
+		}
+	}
+
+	return nil, false
+}
+
+func (f *typeMeta) splitPrimaryKeys() ([]*fieldMeta, []*fieldMeta) {
+	var primaryKeys, columns []*fieldMeta
+	for _, meta := range f.metas {
+		if meta.primaryKey {
+			primaryKeys = append(primaryKeys, meta)
+		} else {
+			columns = append(columns, meta)
+		}
+	}
+
+	return primaryKeys, columns
+}
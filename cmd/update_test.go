@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/cmd/option"
+	"github.com/viant/datly/view"
+	"github.com/viant/xreflect"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBuilder_ExecutorRule(t *testing.T) {
+	dsn := "/tmp/datly_generator_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	for _, SQL := range []string{
+		"CREATE TABLE DEPT (ID INTEGER PRIMARY KEY, NAME TEXT NOT NULL, BUDGET INTEGER)",
+		"CREATE TABLE EMP (ID INTEGER PRIMARY KEY, DEPT_ID INTEGER REFERENCES DEPT(ID), NAME TEXT)",
+		"CREATE TABLE ADDR (ID INTEGER PRIMARY KEY, EMP_ID INTEGER REFERENCES EMP(ID), CITY TEXT)",
+	} {
+		_, err = db.Exec(SQL)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	testcases := []struct {
+		description string
+		method      string
+		relation    bool
+		nested      bool
+		expectSQL   string
+		expectType  string
+	}{
+		{
+			description: "put",
+			method:      PreparePut,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+UPDATE DEPT SET
+NAME = $recDept.Name,
+BUDGET = $recDept.Budget
+WHERE ID = $recDept.Id;
+
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id     int    ` + "`" + `sqlx:"name=ID"` + "`" + `
+	Name   string ` + "`" + `sqlx:"name=NAME"` + "`" + `
+	Budget int    ` + "`" + `sqlx:"name=BUDGET"` + "`" + `
+}
+`,
+		},
+		{
+			description: "patch",
+			method:      PreparePatch,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+UPDATE DEPT SET
+ID = ID
+#if($recDept.Has.Name)
+, NAME = $recDept.Name
+#end
+#if($recDept.Has.Budget)
+, BUDGET = $recDept.Budget
+#end
+WHERE ID = $recDept.Id;
+
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id     int      ` + "`" + `sqlx:"name=ID"` + "`" + `
+	Name   string   ` + "`" + `sqlx:"name=NAME"` + "`" + `
+	Budget int      ` + "`" + `sqlx:"name=BUDGET"` + "`" + `
+	Has    *DeptHas ` + "`" + `jsonIndex:"true" typeName:"DeptHas" json:"-" sqlx:"-"` + "`" + `
+}
+
+type DeptHas struct {
+	Id     bool
+	Name   bool
+	Budget bool
+}
+`,
+		},
+		{
+			description: "delete",
+			method:      PrepareDelete,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+DELETE FROM DEPT
+WHERE ID = $recDept.Id;
+
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id int ` + "`" + `sqlx:"name=ID"` + "`" + `
+}
+`,
+		},
+		{
+			description: "put one to many relation",
+			method:      PreparePut,
+			relation:    true,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+UPDATE DEPT SET
+NAME = $recDept.Name,
+BUDGET = $recDept.Budget
+WHERE ID = $recDept.Id;
+
+	DELETE FROM EMP WHERE DEPT_ID = $recDept.Id
+	#foreach($recEmployees in $recDept.Employees)
+	AND ID <> $recEmployees.Id
+	#end;
+	
+	#foreach($recEmployees in $recDept.Employees)
+	#set($recEmployees.DeptId = $recDept.Id)
+	$upsert.Record("EMP", $recEmployees, "ID");
+	
+	#end
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id        int          ` + "`" + `sqlx:"name=ID"` + "`" + `
+	Name      string       ` + "`" + `sqlx:"name=NAME"` + "`" + `
+	Budget    int          ` + "`" + `sqlx:"name=BUDGET"` + "`" + `
+	Employees []*Employees ` + "`" + `typeName:"Employees" sqlx:"-"` + "`" + `
+}
+
+type Employees struct {
+	Id     int    ` + "`" + `sqlx:"name=ID"` + "`" + `
+	DeptId int    ` + "`" + `sqlx:"name=DEPT_ID"` + "`" + `
+	Name   string ` + "`" + `sqlx:"name=NAME"` + "`" + `
+}
+`,
+		},
+		{
+			description: "put nested one to many relation",
+			method:      PreparePut,
+			relation:    true,
+			nested:      true,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+UPDATE DEPT SET
+NAME = $recDept.Name,
+BUDGET = $recDept.Budget
+WHERE ID = $recDept.Id;
+
+	DELETE FROM ADDR WHERE EMP_ID IN (SELECT ID FROM EMP WHERE DEPT_ID = $recDept.Id
+	#foreach($recEmployees in $recDept.Employees)
+	AND ID <> $recEmployees.Id
+	#end);
+	
+	DELETE FROM EMP WHERE DEPT_ID = $recDept.Id
+	#foreach($recEmployees in $recDept.Employees)
+	AND ID <> $recEmployees.Id
+	#end;
+	
+	#foreach($recEmployees in $recDept.Employees)
+	#set($recEmployees.DeptId = $recDept.Id)
+	$upsert.Record("EMP", $recEmployees, "ID");
+	
+		DELETE FROM ADDR WHERE EMP_ID = $recEmployees.Id
+		#foreach($recAddresses in $recEmployees.Addresses)
+		AND ID <> $recAddresses.Id
+		#end;
+		
+		#foreach($recAddresses in $recEmployees.Addresses)
+		#set($recAddresses.EmpId = $recEmployees.Id)
+		$upsert.Record("ADDR", $recAddresses, "ID");
+		
+		#end
+	#end
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id        int          ` + "`" + `sqlx:"name=ID"` + "`" + `
+	Name      string       ` + "`" + `sqlx:"name=NAME"` + "`" + `
+	Budget    int          ` + "`" + `sqlx:"name=BUDGET"` + "`" + `
+	Employees []*Employees ` + "`" + `typeName:"Employees" sqlx:"-"` + "`" + `
+}
+
+type Employees struct {
+	Id        int          ` + "`" + `sqlx:"name=ID"` + "`" + `
+	DeptId    int          ` + "`" + `sqlx:"name=DEPT_ID"` + "`" + `
+	Name      string       ` + "`" + `sqlx:"name=NAME"` + "`" + `
+	Addresses []*Addresses ` + "`" + `typeName:"Addresses" sqlx:"-"` + "`" + `
+}
+
+type Addresses struct {
+	Id    int    ` + "`" + `sqlx:"name=ID"` + "`" + `
+	EmpId int    ` + "`" + `sqlx:"name=EMP_ID"` + "`" + `
+	City  string ` + "`" + `sqlx:"name=CITY"` + "`" + `
+}
+`,
+		},
+		{
+			description: "delete one to many relation",
+			method:      PrepareDelete,
+			relation:    true,
+			expectSQL: `
+#foreach($recDept in $Unsafe.Dept /* {"Kind":"body","DataType":"Dept","Target":"","Cardinality":"Many"} */ )
+DELETE FROM EMP WHERE DEPT_ID IN (SELECT ID FROM DEPT WHERE ID = $recDept.Id);
+
+DELETE FROM DEPT
+WHERE ID = $recDept.Id;
+
+#end`,
+			expectType: `package generated
+
+type Dept *struct {
+	Id int ` + "`" + `sqlx:"name=ID"` + "`" + `
+}
+`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		config := &viewConfig{expandedTable: &Table{Name: "DEPT", HolderName: "Dept"}, outputConfig: option.OutputConfig{Cardinality: view.Many}}
+		if testcase.relation {
+			config.relations = []*viewConfig{{expandedTable: &Table{Name: "EMP", HolderName: "Employees"}, outputConfig: option.OutputConfig{Cardinality: view.Many}}}
+		}
+		if testcase.nested {
+			config.relations[0].relations = []*viewConfig{{expandedTable: &Table{Name: "ADDR", HolderName: "Addresses"}, outputConfig: option.OutputConfig{Cardinality: view.Many}}}
+		}
+
+		builder := &Builder{routeBuilder: &routeBuilder{option: &option.RouteConfig{}}}
+		def, err := builder.detectInputType(context.Background(), db, "DEPT", config, "", testcase.method)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		rType, err := builder.buildRequestBodyPostParam(config, def)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		SQL, err := newInsertStmtBuilder(&strings.Builder{}, def).build("", true)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expectSQL, SQL, testcase.description)
+		assert.Equal(t, testcase.expectType, xreflect.GenerateStruct(def.typeDef.Name, rType), testcase.description)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/sink"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//routeMethod returns http method of the generated rule
+func routeMethod(prepareRule string) string {
+	if prepareRule == PrepareUpsert {
+		return http.MethodPut
+	}
+
+	return strings.ToUpper(prepareRule)
+}
+
+//readConflictKeys returns upsert conflict columns, table primary key or the first unique index if table has no primary key
+func (s *Builder) readConflictKeys(ctx context.Context, db *sql.DB, tableName string, primaryKeys []sink.Key) ([]string, error) {
+	if len(primaryKeys) > 0 {
+		keys := append([]sink.Key{}, primaryKeys...)
+		sort.SliceStable(keys, func(i, j int) bool {
+			return keys[i].Position < keys[j].Position
+		})
+
+		columns := make([]string, 0, len(keys))
+		for _, aKey := range keys {
+			columns = append(columns, aKey.Column)
+		}
+
+		return columns, nil
+	}
+
+	meta := metadata.New()
+	var indexes []sink.Index
+	if err := meta.Info(ctx, db, info.KindIndexes, &indexes); err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if index.Table != tableName || !isUniqueIndex(index) {
+			continue
+		}
+
+		var columns []string
+		for _, column := range strings.Split(index.Columns, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				columns = append(columns, column)
+			}
+		}
+
+		if len(columns) > 0 {
+			return columns, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate %v rule, table %v has neither primary key nor unique index", PrepareUpsert, tableName)
+}
+
+func isUniqueIndex(index sink.Index) bool {
+	switch strings.ToLower(strings.TrimSpace(index.Unique)) {
+	case "1", "true", "yes", "unique":
+		return true
+	}
+
+	return false
+}
+
+func (isb *insertStmtBuilder) appendUpsert(parentRecord, name string) error {
+	for _, key := range isb.typeDef.conflictKeys {
+		if _, ok := isb.typeDef.meta.metaByColName(key); !ok {
+			return fmt.Errorf("failed to generate %v rule, conflict key %v is not part of %v input", isb.method, key, isb.typeDef.table)
+		}
+	}
+
+	isb.writeString(fmt.Sprintf("\n$upsert.Record(%q, $%v", isb.typeDef.table, isb.accessParam(parentRecord, name, false)))
+	if err := isb.tryWriteParamHint(); err != nil {
+		return err
+	}
+
+	isb.writeString(fmt.Sprintf(", %q);\n", strings.Join(isb.typeDef.conflictKeys, ",")))
+	return nil
+}
@@ -86,6 +86,8 @@ func (s *Builder) buildAndAddView(ctx context.Context, viewConfig *viewConfig, s
 		SelfReference: viewConfig.unexpandedTable.ViewConfig.Self,
 		Cache:         cache,
 		Mode:          viewConfig.viewType,
+		Sequences:     viewConfig.sequences,
+		Tags:          append(viewConfig.unexpandedTable.ViewConfig.Tags, viewConfig.tags...),
 	}
 
 	s.routeBuilder.AddViews(result)
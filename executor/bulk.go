@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/datly/executor/sequencer"
+	"github.com/viant/datly/template/expand"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const defaultInsertBatchSize = 1000
+
+var bulkInsertExpr = regexp.MustCompile(`^` + strings.TrimSpace(expand.BulkInsertStmt) + `\s+(\d+)\s*;?$`)
+
+//bulkInsert returns BulkInsert referenced by the statement, nil for regular statements
+func bulkInsert(SQL string, params *expand.SQLCriteria) (*expand.BulkInsert, error) {
+	matched := bulkInsertExpr.FindStringSubmatch(strings.TrimSpace(SQL))
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	index, _ := strconv.Atoi(matched[1])
+	if params == nil || index >= len(params.Inserts) {
+		return nil, fmt.Errorf("unknown bulk insert #%v", index)
+	}
+
+	return params.Inserts[index], nil
+}
+
+//allocateIDs allocates bulk insert records IDs with sequencer within the executor transaction, right before records are inserted
+func allocateIDs(ctx context.Context, aView *view.View, db *sql.DB, tx *sql.Tx, bulk *expand.BulkInsert) error {
+	if bulk.IDSelector == "" {
+		return nil
+	}
+
+	return sequencer.New(ctx, db, aView.Sequences...).WithTx(tx).Next(bulk.Table, bulk.Records, bulk.IDSelector)
+}
+
+//execBulkInsert inserts records in batches with sqlx insert service, reporting rows affected by each batch
+func execBulkInsert(ctx context.Context, db *sql.DB, tx *sql.Tx, stmt *SQLStatment, batchSize int, result *StatementResult) error {
+	if batchSize <= 0 {
+		batchSize = defaultInsertBatchSize
+	}
+
+	inserter, err := insert.New(ctx, db, stmt.bulk.Table)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches(stmt.bulk.Records, batchSize) {
+		_, size, err := io.Values(batch)
+		if err != nil {
+			return err
+		}
+
+		//insert statement is prepared for batch size records, the last batch can be smaller
+		affected, lastInsertId, err := inserter.Exec(ctx, batch, tx, option.BatchSize(size))
+		if err != nil {
+			return err
+		}
+
+		result.RowsAffected += affected
+		result.Batches = append(result.Batches, affected)
+		if lastInsertId != 0 {
+			result.LastInsertId = lastInsertId
+		}
+	}
+
+	return nil
+}
+
+//batches splits records slice into slices of batchSize records
+func batches(records interface{}, batchSize int) []interface{} {
+	rValue := reflect.ValueOf(records)
+	if rValue.Kind() == reflect.Ptr && rValue.Elem().Kind() == reflect.Slice {
+		rValue = rValue.Elem()
+	}
+
+	if rValue.Kind() != reflect.Slice {
+		return []interface{}{records}
+	}
+
+	result := make([]interface{}, 0, rValue.Len()/batchSize+1)
+	for i := 0; i < rValue.Len(); i += batchSize {
+		end := i + batchSize
+		if end > rValue.Len() {
+			end = rValue.Len()
+		}
+
+		result = append(result, rValue.Slice(i, end).Interface())
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/template/expand"
+	"github.com/viant/datly/view"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"os"
+	"testing"
+)
+
+type bulkEvent struct {
+	Id   int    `sqlx:"name=ID,primaryKey=true"`
+	Name string `sqlx:"name=NAME"`
+}
+
+func TestBulkInsert(t *testing.T) {
+	params := &expand.SQLCriteria{Inserts: []*expand.BulkInsert{{Table: "EVENTS"}}}
+	testcases := []struct {
+		description string
+		SQL         string
+		expectBulk  bool
+		expectErr   bool
+	}{
+		{description: "bulk insert", SQL: "SQLX INSERT 0;", expectBulk: true},
+		{description: "bulk insert with whitespaces", SQL: "  SQLX INSERT 0 ", expectBulk: true},
+		{description: "unknown bulk insert", SQL: "SQLX INSERT 1", expectErr: true},
+		{description: "regular statement", SQL: "INSERT INTO EVENTS(ID) VALUES (1)"},
+	}
+
+	for _, testcase := range testcases {
+		bulk, err := bulkInsert(testcase.SQL, params)
+		assert.Equal(t, testcase.expectErr, err != nil, testcase.description)
+		assert.Equal(t, testcase.expectBulk, bulk != nil, testcase.description)
+	}
+}
+
+func TestExecutor_ExecBulkInsert(t *testing.T) {
+	dsn := "/tmp/datly_executor_bulk_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	testcases := []struct {
+		description    string
+		records        int
+		batchSize      int
+		expectBatches  []int64
+		expectAffected int64
+	}{
+		{
+			description:    "multiple batches",
+			records:        5,
+			batchSize:      2,
+			expectBatches:  []int64{2, 2, 1},
+			expectAffected: 5,
+		},
+		{
+			description:    "default batch size",
+			records:        3,
+			expectBatches:  []int64{3},
+			expectAffected: 3,
+		},
+	}
+
+	for _, testcase := range testcases {
+		for _, SQL := range []string{"DROP TABLE IF EXISTS EVENTS", "CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT)"} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		var records []*bulkEvent
+		for i := 0; i < testcase.records; i++ {
+			records = append(records, &bulkEvent{Id: i + 1, Name: "abc"})
+		}
+
+		stmt := &SQLStatment{SQL: "SQLX INSERT 0", bulk: &expand.BulkInsert{Table: "EVENTS", Records: records}}
+		session := &Session{View: &view.View{}, BatchSize: testcase.batchSize}
+		err = New().execSequential(context.TODO(), db, session, []*SQLStatment{stmt})
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		result := session.Result()
+		assert.Equal(t, testcase.expectAffected, result.RowsAffected, testcase.description)
+		if assert.Len(t, result.Statements, 1, testcase.description) {
+			assert.Equal(t, testcase.expectBatches, result.Statements[0].Batches, testcase.description)
+		}
+
+		count := 0
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM EVENTS").Scan(&count), testcase.description)
+		assert.Equal(t, testcase.records, count, testcase.description)
+	}
+}
@@ -0,0 +1,50 @@
+package executor
+
+import "fmt"
+
+//StatementError identifies failed template statement
+type StatementError struct {
+	Position int    //1-based statement position in the template
+	SQL      string `json:"-"`
+	Err      error  `json:"-"`
+}
+
+func (e *StatementError) Error() string {
+	return fmt.Sprintf("failed to execute statement #%v: %v", e.Position, e.Err)
+}
+
+func (e *StatementError) Unwrap() error {
+	return e.Err
+}
+
+//NewStatementError creates StatementError for statement at given index
+func NewStatementError(index int, stmt *SQLStatment, err error) *StatementError {
+	return &StatementError{
+		Position: index + 1,
+		SQL:      stmt.SQL,
+		Err:      err,
+	}
+}
+
+//ConflictError reports statement guarded by optimistic lock that did not update any record
+type ConflictError struct {
+	Column string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("optimistic lock conflict, record was modified or %v value is outdated", e.Column)
+}
+
+//PartialCommitError reports ParallelMode commit failure after some statements were already committed
+type PartialCommitError struct {
+	Committed []int //1-based positions of committed statements
+	Err       error
+}
+
+func (e *PartialCommitError) Error() string {
+	return fmt.Sprintf("%v mode is not atomic, statements %v were committed before failure: %v", ParallelMode, e.Committed, e.Err)
+}
+
+func (e *PartialCommitError) Unwrap() error {
+	return e.Err
+}
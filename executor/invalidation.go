@@ -0,0 +1,18 @@
+package executor
+
+import (
+	"context"
+	"github.com/viant/datly/view"
+)
+
+//invalidateCache purges caches tagged with executor View tags once transaction is committed
+func (e *Executor) invalidateCache(ctx context.Context, session *Session) {
+	tags := session.View.RelatedTags()
+	if len(tags) == 0 || len(session.Results) == 0 || session.DryRun {
+		return
+	}
+
+	if _, err := view.InvalidateTags(ctx, tags...); err != nil {
+		session.View.Logger.Log("failed to invalidate %v cache tags: %v\n", tags, err)
+	}
+}
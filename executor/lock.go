@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/dialect"
+	"regexp"
+	"strings"
+)
+
+var (
+	updateExpr = regexp.MustCompile(`(?is)^\s*UPDATE\s`)
+	whereExpr  = regexp.MustCompile(`(?i)\bWHERE\b`)
+)
+
+//lockStatement rewrites UPDATE statement assigning bound lock column value, the bound value becomes expected value
+//i.e. UPDATE T SET NAME = ?, VERSION = ? WHERE ID = ? -> UPDATE T SET NAME = ?, VERSION = VERSION + 1 WHERE (ID = ?) AND VERSION = ?
+//RETURNING clause is kept after the rewritten criteria
+func lockStatement(stmt *SQLStatment, lock *view.OptimisticLock, aDialect *dialect.Dialect) bool {
+	SQL := stmt.SQL
+	if lock == nil || !updateExpr.MatchString(SQL) {
+		return false
+	}
+
+	topLevel, placeholders := scanStatement(SQL)
+	returningAt := len(SQL)
+	for _, loc := range returningExpr.FindAllStringIndex(SQL, -1) {
+		if topLevel[loc[0]] {
+			returningAt = loc[0]
+			break
+		}
+	}
+
+	returning := strings.TrimRight(strings.TrimSpace(SQL[returningAt:]), ";")
+	SQL = SQL[:returningAt]
+	whereAt := len(SQL)
+	for _, loc := range whereExpr.FindAllStringIndex(SQL, -1) {
+		if topLevel[loc[0]] {
+			whereAt = loc[0]
+			break
+		}
+	}
+
+	column := aDialect.Quote(lock.Column)
+	assignmentExpr, err := regexp.Compile(`(?i)(\w+\.)?(\b` + regexp.QuoteMeta(lock.Column) + `|` + regexp.QuoteMeta(column) + `)\s*=\s*\?`)
+	if err != nil {
+		return false
+	}
+
+	for _, loc := range assignmentExpr.FindAllStringIndex(SQL[:whereAt], -1) {
+		if !topLevel[loc[1]-1] {
+			continue
+		}
+
+		argIndex := placeholderIndex(placeholders, loc[1]-1)
+		returningIndex := placeholderCount(placeholders, returningAt)
+		if argIndex == -1 || argIndex >= len(stmt.Args) || returningIndex > len(stmt.Args) {
+			return false
+		}
+
+		returningArgs := stmt.Args[returningIndex:]
+		expected := stmt.Args[argIndex]
+		args := make([]interface{}, 0, len(stmt.Args))
+		args = append(args, stmt.Args[:argIndex]...)
+		args = append(args, stmt.Args[argIndex+1:len(stmt.Args)-len(returningArgs)]...)
+		args = append(args, expected)
+		args = append(args, returningArgs...)
+
+		sb := &strings.Builder{}
+		sb.WriteString(SQL[:loc[0]])
+		sb.WriteString(lock.Increment(aDialect))
+		sb.WriteString(strings.TrimRight(SQL[loc[1]:whereAt], " \t\n"))
+		sb.WriteString(" WHERE ")
+		if criteria := strings.TrimRight(strings.TrimSpace(SQL[whereAt:]), ";"); criteria != "" {
+			sb.WriteString("(")
+			sb.WriteString(strings.TrimSpace(criteria[len("WHERE"):]))
+			sb.WriteString(") AND ")
+		}
+
+		sb.WriteString(column)
+		sb.WriteString(" = ?")
+		if returning != "" {
+			sb.WriteString(" ")
+			sb.WriteString(returning)
+		}
+
+		stmt.SQL = sb.String()
+		stmt.Args = args
+		stmt.lock = lock
+		return true
+	}
+
+	return false
+}
+
+//scanStatement returns flags marking SQL positions outside quotes and brackets, and placeholders positions
+func scanStatement(SQL string) ([]bool, []int) {
+	topLevel := make([]bool, len(SQL))
+	var placeholders []int
+	var quote byte
+	depth := 0
+	for i := 0; i < len(SQL); i++ {
+		c := SQL[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			continue
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '?':
+			placeholders = append(placeholders, i)
+		}
+
+		topLevel[i] = depth == 0
+	}
+
+	return topLevel, placeholders
+}
+
+//placeholderCount returns number of placeholders before given position
+func placeholderCount(placeholders []int, position int) int {
+	for i, placeholder := range placeholders {
+		if placeholder >= position {
+			return i
+		}
+	}
+
+	return len(placeholders)
+}
+
+func placeholderIndex(placeholders []int, position int) int {
+	for i, placeholder := range placeholders {
+		if placeholder == position {
+			return i
+		}
+	}
+
+	return -1
+}
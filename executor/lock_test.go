@@ -0,0 +1,227 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/dialect"
+	"os"
+	"testing"
+)
+
+func TestLockStatement(t *testing.T) {
+	testcases := []struct {
+		description string
+		lock        *view.OptimisticLock
+		dialect     *dialect.Dialect
+		SQL         string
+		args        []interface{}
+		expectSQL   string
+		expectArgs  []interface{}
+	}{
+		{
+			description: "version lock",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			SQL:         "UPDATE EVENTS SET NAME = ?, VERSION = ? WHERE ID = ? OR ID = ?",
+			args:        []interface{}{"abc", 3, 1, 2},
+			expectSQL:   "UPDATE EVENTS SET NAME = ?, VERSION = VERSION + 1 WHERE (ID = ? OR ID = ?) AND VERSION = ?",
+			expectArgs:  []interface{}{"abc", 1, 2, 3},
+		},
+		{
+			description: "updated at lock without criteria",
+			lock:        &view.OptimisticLock{Column: "UPDATED", Kind: view.UpdatedAtLock},
+			SQL:         "UPDATE EVENTS SET UPDATED = ?, NAME = 'VERSION = ?'",
+			args:        []interface{}{"2022-01-01"},
+			expectSQL:   "UPDATE EVENTS SET UPDATED = CURRENT_TIMESTAMP, NAME = 'VERSION = ?' WHERE UPDATED = ?",
+			expectArgs:  []interface{}{"2022-01-01"},
+		},
+		{
+			description: "lock column in criteria only",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			SQL:         "UPDATE EVENTS SET NAME = ? WHERE VERSION = ?",
+			args:        []interface{}{"abc", 3},
+		},
+		{
+			description: "returning",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			dialect:     dialect.PostgreSQL,
+			SQL:         "UPDATE EVENTS SET NAME = ?, VERSION = ? WHERE ID = ? RETURNING ID, VERSION;",
+			args:        []interface{}{"abc", 3, 1},
+			expectSQL:   "UPDATE EVENTS SET NAME = ?, VERSION = VERSION + 1 WHERE (ID = ?) AND VERSION = ? RETURNING ID, VERSION",
+			expectArgs:  []interface{}{"abc", 1, 3},
+		},
+		{
+			description: "returning without criteria",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			dialect:     dialect.SQLite,
+			SQL:         "UPDATE EVENTS SET VERSION = ? RETURNING VERSION + ? AS NEXT",
+			args:        []interface{}{3, 1},
+			expectSQL:   "UPDATE EVENTS SET VERSION = VERSION + 1 WHERE VERSION = ? RETURNING VERSION + ? AS NEXT",
+			expectArgs:  []interface{}{3, 1},
+		},
+		{
+			description: "output",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			dialect:     dialect.SQLServer,
+			SQL:         "UPDATE EVENTS SET VERSION = ? OUTPUT INSERTED.VERSION WHERE ID = ?",
+			args:        []interface{}{3, 1},
+			expectSQL:   "UPDATE EVENTS SET VERSION = VERSION + 1 OUTPUT INSERTED.VERSION WHERE (ID = ?) AND VERSION = ?",
+			expectArgs:  []interface{}{1, 3},
+		},
+		{
+			description: "quoted reserved lock column",
+			lock:        &view.OptimisticLock{Column: "CURRENT", Kind: view.VersionLock},
+			dialect:     dialect.MySQL,
+			SQL:         "UPDATE EVENTS SET NAME = ?, `CURRENT` = ? WHERE ID = ?",
+			args:        []interface{}{"abc", 3, 1},
+			expectSQL:   "UPDATE EVENTS SET NAME = ?, `CURRENT` = `CURRENT` + 1 WHERE (ID = ?) AND `CURRENT` = ?",
+			expectArgs:  []interface{}{"abc", 1, 3},
+		},
+		{
+			description: "insert statement",
+			lock:        &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock},
+			SQL:         "INSERT INTO EVENTS(NAME, VERSION) VALUES (?, ?)",
+			args:        []interface{}{"abc", 1},
+		},
+	}
+
+	for _, testcase := range testcases {
+		aDialect := testcase.dialect
+		if aDialect == nil {
+			aDialect = dialect.ANSI
+		}
+
+		stmt := &SQLStatment{SQL: testcase.SQL, Args: testcase.args}
+		locked := lockStatement(stmt, testcase.lock, aDialect)
+		if testcase.expectSQL == "" {
+			assert.False(t, locked, testcase.description)
+			assert.Equal(t, testcase.SQL, stmt.SQL, testcase.description)
+			continue
+		}
+
+		assert.True(t, locked, testcase.description)
+		assert.Equal(t, testcase.expectSQL, stmt.SQL, testcase.description)
+		assert.Equal(t, testcase.expectArgs, stmt.Args, testcase.description)
+	}
+}
+
+func TestExecutor_ExecLocked(t *testing.T) {
+	dsn := "/tmp/datly_executor_lock_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	testcases := []struct {
+		description    string
+		expected       string
+		args           []interface{}
+		savepoint      bool
+		expectConflict bool
+		expectVersion  int
+	}{
+		{
+			description:   "expected version",
+			args:          []interface{}{"def", 1, 1},
+			expectVersion: 2,
+		},
+		{
+			description:    "outdated version",
+			args:           []interface{}{"def", 0, 1},
+			expectConflict: true,
+			expectVersion:  1,
+		},
+		{
+			description:   "expected version overridden",
+			expected:      "1",
+			args:          []interface{}{"def", 0, 1},
+			expectVersion: 2,
+		},
+		{
+			description:    "outdated version in savepoint block",
+			args:           []interface{}{"def", 0, 1},
+			savepoint:      true,
+			expectConflict: true,
+			expectVersion:  1,
+		},
+	}
+
+	lock := &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock}
+	for _, testcase := range testcases {
+		for _, SQL := range []string{"DROP TABLE IF EXISTS EVENTS", "CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT, VERSION INTEGER)", "INSERT INTO EVENTS(ID, NAME, VERSION) VALUES (1, 'abc', 1)"} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		stmt := &SQLStatment{SQL: "UPDATE EVENTS SET NAME = ?, VERSION = ? WHERE ID = ?", Args: testcase.args}
+		assert.True(t, lockStatement(stmt, lock, dialect.SQLite), testcase.description)
+
+		statements := []*SQLStatment{stmt}
+		if testcase.savepoint {
+			statements = []*SQLStatment{{SQL: "SAVEPOINT block1"}, stmt, {SQL: "RELEASE SAVEPOINT block1"}}
+		}
+
+		session := &Session{View: &view.View{}, Expected: testcase.expected}
+		assert.Nil(t, session.expectLocked(statements), testcase.description)
+		err = New().execSequential(context.TODO(), db, session, statements)
+
+		var conflictErr *ConflictError
+		assert.Equal(t, testcase.expectConflict, errors.As(err, &conflictErr), testcase.description)
+
+		version := 0
+		assert.Nil(t, db.QueryRow("SELECT VERSION FROM EVENTS WHERE ID = 1").Scan(&version), testcase.description)
+		assert.Equal(t, testcase.expectVersion, version, testcase.description)
+	}
+}
+
+func TestSession_ExpectLocked(t *testing.T) {
+	lock := &view.OptimisticLock{Column: "VERSION", Kind: view.VersionLock}
+	testcases := []struct {
+		description string
+		expected    string
+		statements  []*SQLStatment
+		expectArgs  []interface{}
+		expectErr   bool
+	}{
+		{
+			description: "not expected",
+			statements:  []*SQLStatment{{Args: []interface{}{1, 1}, lock: lock}, {Args: []interface{}{2, 2}, lock: lock}},
+			expectArgs:  []interface{}{1, 1},
+		},
+		{
+			description: "single locked statement",
+			expected:    "3",
+			statements:  []*SQLStatment{{Args: []interface{}{1, 1}, lock: lock}, {Args: []interface{}{2}}},
+			expectArgs:  []interface{}{1, "3"},
+		},
+		{
+			description: "many locked statements",
+			expected:    "3",
+			statements:  []*SQLStatment{{Args: []interface{}{1, 1}, lock: lock}, {Args: []interface{}{2, 2}, lock: lock}},
+			expectErr:   true,
+		},
+		{
+			description: "no locked statement",
+			expected:    "3",
+			statements:  []*SQLStatment{{Args: []interface{}{1}}},
+			expectErr:   true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		session := &Session{Expected: testcase.expected}
+		err := session.expectLocked(testcase.statements)
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			continue
+		}
+
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, testcase.expectArgs, testcase.statements[0].Args, testcase.description)
+	}
+}
@@ -0,0 +1,31 @@
+package executor
+
+import (
+	"context"
+	"github.com/viant/datly/executor/relay"
+)
+
+//deliverEvents publishes outbox events once executor transaction is committed
+//undelivered events stay in the outbox table and are retried with the next or background delivery
+func (e *Executor) deliverEvents(ctx context.Context, session *Session) {
+	config := session.View.Outbox
+	if config == nil || session.DryRun {
+		return
+	}
+
+	db, err := session.View.Db()
+	if err != nil {
+		session.View.Logger.Log("failed to deliver %v outbox events: %v\n", config.Table, err)
+		return
+	}
+
+	aLogger := session.View.Logger
+	aRelay := relay.Of(config, db, session.View.Dialect())
+	aRelay.Start(func(err error) {
+		aLogger.Log("failed to deliver %v outbox events: %v\n", config.Table, err)
+	})
+
+	if session.Delivery, err = aRelay.Deliver(ctx); err != nil {
+		aLogger.Log("failed to deliver %v outbox events: %v\n", config.Table, err)
+	}
+}
@@ -0,0 +1,107 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"github.com/viant/datly/view/outbox"
+	"strings"
+	"sync"
+)
+
+const memScheme = "mem://"
+
+var publishers = &registry{index: map[string]Publisher{}}
+
+type (
+	//Publisher delivers outbox events, returned error causes event to be retried
+	Publisher interface {
+		Publish(ctx context.Context, event *outbox.Event) error
+	}
+
+	//Memory publisher keeps published events in memory
+	Memory struct {
+		mux    sync.Mutex
+		events []*outbox.Event
+		Err    error //if set, Publish fails with Err
+	}
+
+	//Afs publisher writes events to <URL>/<Topic>/<ID>.json
+	Afs struct {
+		URL string
+		fs  afs.Service
+	}
+
+	registry struct {
+		mux   sync.Mutex
+		index map[string]Publisher
+	}
+)
+
+//Publish stores event in memory
+func (m *Memory) Publish(_ context.Context, event *outbox.Event) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.events = append(m.events, event)
+	return nil
+}
+
+//Events returns published events
+func (m *Memory) Events() []*outbox.Event {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]*outbox.Event{}, m.events...)
+}
+
+//Publish writes event to the afs location
+func (a *Afs) Publish(ctx context.Context, event *outbox.Event) error {
+	return upload(ctx, a.fs, a.URL, event)
+}
+
+//NewAfs creates afs publisher
+func NewAfs(URL string) *Afs {
+	return &Afs{URL: URL, fs: afs.New()}
+}
+
+//Register registers publisher under the URL, registered publishers take precedence over built-in ones
+func Register(URL string, publisher Publisher) {
+	publishers.mux.Lock()
+	defer publishers.mux.Unlock()
+	publishers.index[URL] = publisher
+}
+
+//Lookup returns publisher registered under the URL, mem://<name> returns shared in-memory publisher,
+//any other URL is used as afs publisher location
+func Lookup(URL string) Publisher {
+	publishers.mux.Lock()
+	defer publishers.mux.Unlock()
+	if publisher, ok := publishers.index[URL]; ok {
+		return publisher
+	}
+
+	var publisher Publisher
+	if strings.HasPrefix(URL, memScheme) {
+		publisher = &Memory{}
+	} else {
+		publisher = NewAfs(URL)
+	}
+
+	publishers.index[URL] = publisher
+	return publisher
+}
+
+func upload(ctx context.Context, fs afs.Service, baseURL string, event *outbox.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return fs.Upload(ctx, url.Join(baseURL, event.Topic, event.ID+".json"), file.DefaultFileOsMode, bytes.NewReader(data))
+}
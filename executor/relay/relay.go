@@ -0,0 +1,184 @@
+package relay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/afs"
+	"github.com/viant/datly/view/dialect"
+	"github.com/viant/datly/view/outbox"
+	"sync"
+	"time"
+)
+
+var relays = &relayRegistry{index: map[*outbox.Config]*Relay{}}
+
+type (
+	//Delivery summarizes outbox delivery
+	Delivery struct {
+		Published    int
+		Retried      int
+		DeadLettered int
+	}
+
+	//Relay delivers pending outbox events to the publisher, deliveries of the same Relay are serialized
+	Relay struct {
+		config    *outbox.Config
+		db        *sql.DB
+		dialect   *dialect.Dialect
+		publisher Publisher
+		fs        afs.Service
+		mux       sync.Mutex
+		start     sync.Once
+		stop      sync.Once
+		done      chan bool
+		running   sync.WaitGroup
+	}
+
+	relayRegistry struct {
+		mux   sync.Mutex
+		index map[*outbox.Config]*Relay
+	}
+)
+
+//Deliver publishes pending outbox events in creation order, published events are removed from the outbox table
+//failed events are retried with the next delivery, and moved to the dead-letter location after MaxAttempts
+//events are delivered at least once, publisher should be idempotent with respect to the event ID
+func (r *Relay) Deliver(ctx context.Context) (*Delivery, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	events, err := r.pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &Delivery{}
+	for _, event := range events {
+		if err = r.deliver(ctx, event, delivery); err != nil {
+			return delivery, err
+		}
+	}
+
+	return delivery, nil
+}
+
+//Start delivers pending events in the background every RelayIntervalMs, so that failed events are retried
+//even if no other executor request follows, background delivery is started once, onError handles delivery errors
+func (r *Relay) Start(onError func(err error)) {
+	r.start.Do(func() {
+		r.running.Add(1)
+		go r.run(onError)
+	})
+}
+
+//Stop stops background delivery and waits for the in-flight delivery to complete
+func (r *Relay) Stop() {
+	r.stop.Do(func() {
+		close(r.done)
+	})
+	r.running.Wait()
+}
+
+func (r *Relay) run(onError func(err error)) {
+	defer r.running.Done()
+	ticker := time.NewTicker(time.Duration(r.config.RelayIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if _, err := r.Deliver(context.Background()); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (r *Relay) deliver(ctx context.Context, event *outbox.Event, delivery *Delivery) error {
+	publishErr := r.publisher.Publish(ctx, event)
+	if publishErr == nil {
+		delivery.Published++
+		return r.remove(ctx, event)
+	}
+
+	event.Attempts++
+	if event.Attempts < r.config.MaxAttempts {
+		delivery.Retried++
+		_, err := r.db.ExecContext(ctx, r.dialect.EnsurePlaceholders("UPDATE "+r.config.Table+" SET ATTEMPTS = ? WHERE ID = ?"), event.Attempts, event.ID)
+		return err
+	}
+
+	if err := r.deadLetter(ctx, event); err != nil {
+		return fmt.Errorf("failed to dead-letter %v event %v: %w, publish error: %v", event.Topic, event.ID, err, publishErr)
+	}
+
+	delivery.DeadLettered++
+	return r.remove(ctx, event)
+}
+
+func (r *Relay) deadLetter(ctx context.Context, event *outbox.Event) error {
+	if r.config.DeadLetterURL == "" {
+		return nil
+	}
+
+	return upload(ctx, r.fs, r.config.DeadLetterURL, event)
+}
+
+func (r *Relay) remove(ctx context.Context, event *outbox.Event) error {
+	_, err := r.db.ExecContext(ctx, r.dialect.EnsurePlaceholders("DELETE FROM "+r.config.Table+" WHERE ID = ?"), event.ID)
+	return err
+}
+
+func (r *Relay) pending(ctx context.Context) ([]*outbox.Event, error) {
+	rows, err := r.db.QueryContext(ctx, r.pendingSQL())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*outbox.Event
+	for rows.Next() {
+		event := &outbox.Event{}
+		if err = rows.Scan(&event.ID, &event.Topic, &event.Payload, &event.CreatedAt, &event.Attempts); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+//pendingSQL returns the oldest BatchSize events query, paginated with the dialect syntax
+func (r *Relay) pendingSQL() string {
+	limit := r.config.BatchSize
+	SQL := "SELECT " + r.dialect.Top(limit, 0) + "ID, TOPIC, PAYLOAD, CREATED_AT, ATTEMPTS FROM " + r.config.Table +
+		" ORDER BY CREATED_AT" + r.dialect.Paginate(limit, 0)
+
+	return r.dialect.WrapPagination(SQL, limit, 0)
+}
+
+//New creates outbox Relay, config has to be initialized
+func New(config *outbox.Config, db *sql.DB, aDialect *dialect.Dialect) *Relay {
+	return &Relay{
+		config:    config,
+		db:        db,
+		dialect:   aDialect,
+		publisher: Lookup(config.PublisherURL),
+		fs:        afs.New(),
+		done:      make(chan bool),
+	}
+}
+
+//Of returns Relay shared by all deliveries of the outbox config
+func Of(config *outbox.Config, db *sql.DB, aDialect *dialect.Dialect) *Relay {
+	relays.mux.Lock()
+	defer relays.mux.Unlock()
+	aRelay, ok := relays.index[config]
+	if !ok {
+		aRelay = New(config, db, aDialect)
+		relays.index[config] = aRelay
+	}
+
+	return aRelay
+}
@@ -0,0 +1,205 @@
+package relay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/datly/view/dialect"
+	"github.com/viant/datly/view/outbox"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestRelay_Deliver(t *testing.T) {
+	dsn := "/tmp/datly_outbox_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	deadLetterURL := path.Join(os.TempDir(), "datly_outbox_dead_letter")
+	testcases := []struct {
+		description      string
+		publisher        *Memory
+		deliveries       int
+		expectDelivery   *Delivery
+		expectPublished  int
+		expectPending    int
+		expectDeadLetter int
+	}{
+		{
+			description:     "published events",
+			publisher:       &Memory{},
+			deliveries:      1,
+			expectDelivery:  &Delivery{Published: 2},
+			expectPublished: 2,
+		},
+		{
+			description:    "retried events",
+			publisher:      &Memory{Err: fmt.Errorf("unavailable")},
+			deliveries:     2,
+			expectDelivery: &Delivery{Retried: 2},
+			expectPending:  2,
+		},
+		{
+			description:      "dead-lettered events",
+			publisher:        &Memory{Err: fmt.Errorf("unavailable")},
+			deliveries:       3,
+			expectDelivery:   &Delivery{DeadLettered: 2},
+			expectDeadLetter: 2,
+		},
+	}
+
+	fs := afs.New()
+	for i, testcase := range testcases {
+		_ = os.RemoveAll(deadLetterURL)
+		for _, SQL := range []string{"DROP TABLE IF EXISTS OUTBOX", "CREATE TABLE OUTBOX (ID TEXT PRIMARY KEY, TOPIC TEXT, PAYLOAD TEXT, CREATED_AT DATETIME, ATTEMPTS INTEGER)"} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		publisherURL := fmt.Sprintf("mem://outbox_test_%v", i)
+		Register(publisherURL, testcase.publisher)
+		config := &outbox.Config{PublisherURL: publisherURL, DeadLetterURL: deadLetterURL}
+		if !assert.Nil(t, config.Init(), testcase.description) {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+		for _, ID := range []int{1, 2} {
+			event, err := outbox.NewEvent("orders", map[string]int{"ID": ID})
+			assert.Nil(t, err, testcase.description)
+			SQL, args := event.InsertSQL(config.Table)
+			_, err = tx.Exec(SQL, args...)
+			assert.Nil(t, err, testcase.description)
+		}
+		assert.Nil(t, tx.Commit(), testcase.description)
+
+		aRelay := New(config, db, dialect.SQLite)
+		var delivery *Delivery
+		for j := 0; j < testcase.deliveries; j++ {
+			delivery, err = aRelay.Deliver(context.TODO())
+			assert.Nil(t, err, testcase.description)
+		}
+
+		assert.Equal(t, testcase.expectDelivery, delivery, testcase.description)
+		assert.Equal(t, testcase.expectPublished, len(testcase.publisher.Events()), testcase.description)
+
+		pending := 0
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM OUTBOX").Scan(&pending), testcase.description)
+		assert.Equal(t, testcase.expectPending, pending, testcase.description)
+
+		deadLetters, _ := fs.List(context.TODO(), path.Join(deadLetterURL, "orders"))
+		deadLetterCount := 0
+		for _, object := range deadLetters {
+			if !object.IsDir() {
+				deadLetterCount++
+			}
+		}
+		assert.Equal(t, testcase.expectDeadLetter, deadLetterCount, testcase.description)
+	}
+}
+
+func TestAfs_Publish(t *testing.T) {
+	URL := path.Join(os.TempDir(), "datly_outbox_publisher")
+	_ = os.RemoveAll(URL)
+
+	event, err := outbox.NewEvent("orders", map[string]int{"ID": 1})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, NewAfs(URL).Publish(context.TODO(), event))
+	data, err := os.ReadFile(path.Join(URL, "orders", event.ID+".json"))
+	if assert.Nil(t, err) {
+		assert.Contains(t, string(data), `"Payload":"{\"ID\":1}"`)
+	}
+}
+
+func TestRelay_Start(t *testing.T) {
+	dsn := "/tmp/datly_relay_start_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	for _, SQL := range []string{"CREATE TABLE OUTBOX (ID TEXT PRIMARY KEY, TOPIC TEXT, PAYLOAD TEXT, CREATED_AT DATETIME, ATTEMPTS INTEGER)"} {
+		_, err = db.Exec(SQL)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	publisher := &Memory{}
+	Register("mem://relay_start_test", publisher)
+	config := &outbox.Config{PublisherURL: "mem://relay_start_test", RelayIntervalMs: 10}
+	if !assert.Nil(t, config.Init()) {
+		return
+	}
+
+	event, err := outbox.NewEvent("orders", map[string]int{"ID": 1})
+	if !assert.Nil(t, err) {
+		return
+	}
+	SQL, args := event.InsertSQL(config.Table)
+	_, err = db.Exec(SQL, args...)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	aRelay := New(config, db, dialect.SQLite)
+	aRelay.Start(func(err error) {
+		assert.Nil(t, err)
+	})
+	defer aRelay.Stop()
+
+	for i := 0; i < 100 && len(publisher.Events()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 1, len(publisher.Events()))
+}
+
+func TestRelay_PendingSQL(t *testing.T) {
+	testcases := []struct {
+		description string
+		dialect     *dialect.Dialect
+		expect      string
+	}{
+		{
+			description: "limit offset",
+			dialect:     dialect.PostgreSQL,
+			expect:      "SELECT ID, TOPIC, PAYLOAD, CREATED_AT, ATTEMPTS FROM OUTBOX ORDER BY CREATED_AT LIMIT 100",
+		},
+		{
+			description: "top",
+			dialect:     dialect.SQLServer,
+			expect:      "SELECT TOP 100 ID, TOPIC, PAYLOAD, CREATED_AT, ATTEMPTS FROM OUTBOX ORDER BY CREATED_AT",
+		},
+		{
+			description: "rownum",
+			dialect:     dialect.Oracle,
+			expect:      "SELECT * FROM (SELECT ID, TOPIC, PAYLOAD, CREATED_AT, ATTEMPTS FROM OUTBOX ORDER BY CREATED_AT) WHERE ROWNUM <= 100",
+		},
+	}
+
+	for _, testcase := range testcases {
+		config := &outbox.Config{PublisherURL: "mem://relay_pending_sql_test"}
+		if !assert.Nil(t, config.Init(), testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, New(config, nil, testcase.dialect).pendingSQL(), testcase.description)
+	}
+}
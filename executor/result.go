@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"github.com/viant/datly/executor/relay"
+	"regexp"
+)
+
+var (
+	returningExpr = regexp.MustCompile(`(?i)\bRETURNING\b`)
+	outputExpr    = regexp.MustCompile(`(?i)\bOUTPUT\s+(INSERTED|DELETED)\.`)
+)
+
+type (
+	//Result summarises executed statements
+	Result struct {
+		RowsAffected int64
+		LastInsertId int64              `json:",omitempty"` //last generated ID reported by the driver
+		Statements   []*StatementResult `json:",omitempty"`
+		Outbox       *relay.Delivery    `json:",omitempty"` //outbox events delivered after commit
+	}
+
+	//StatementResult describes executed statement
+	StatementResult struct {
+		Position     int
+		SQL          string        `json:",omitempty"`
+		Args         []interface{} `json:",omitempty"`
+		RowsAffected int64
+		LastInsertId int64                    `json:",omitempty"`
+		Returning    []map[string]interface{} `json:",omitempty"` //rows returned by RETURNING / OUTPUT clause
+		Batches      []int64                  `json:",omitempty"` //rows affected by each bulk insert batch
+	}
+)
+
+//hasReturning returns true if statement uses RETURNING or OUTPUT clause, quoted literals are ignored
+func hasReturning(SQL string) bool {
+	unquoted := stripQuoted(SQL)
+	return returningExpr.MatchString(unquoted) || outputExpr.MatchString(unquoted)
+}
+
+func stripQuoted(SQL string) string {
+	result := []byte(SQL)
+	var quote byte
+	for i := 0; i < len(result); i++ {
+		c := result[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+
+			result[i] = ' '
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		}
+	}
+
+	return string(result)
+}
+
+func execStatement(ctx context.Context, tx *sql.Tx, stmt *SQLStatment, result *StatementResult) error {
+	sqlResult, err := tx.ExecContext(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		return err
+	}
+
+	result.RowsAffected, _ = sqlResult.RowsAffected()
+	result.LastInsertId, _ = sqlResult.LastInsertId()
+	return nil
+}
+
+func queryReturning(ctx context.Context, tx *sql.Tx, stmt *SQLStatment, result *StatementResult) error {
+	rows, err := tx.QueryContext(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if data, ok := values[i].([]byte); ok {
+				values[i] = string(data)
+			}
+
+			record[column] = values[i]
+		}
+
+		result.Returning = append(result.Returning, record)
+	}
+
+	result.RowsAffected = int64(len(result.Returning))
+	return rows.Err()
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	savepointStmt = iota + 1
+	releaseStmt
+)
+
+var savepointExpr = regexp.MustCompile(`(?i)^(SAVEPOINT|RELEASE\s+SAVEPOINT|RELEASE)\s+([A-Za-z_][A-Za-z0-9_]*)\s*;?$`)
+
+//savepoints tracks open savepoint blocks of the sequential execution
+type savepoints struct {
+	names   []string
+	offsets []int //number of statement results at savepoint creation
+	skipped string
+}
+
+func savepointKind(SQL string) (int, string) {
+	matched := savepointExpr.FindStringSubmatch(strings.TrimSpace(SQL))
+	if len(matched) == 0 {
+		return 0, ""
+	}
+
+	if strings.EqualFold(matched[1], "SAVEPOINT") {
+		return savepointStmt, matched[2]
+	}
+
+	return releaseStmt, matched[2]
+}
+
+//skip returns true if statement belongs to the block that was rolled back
+func (s *savepoints) skip(kind int, name string) bool {
+	if s.skipped == "" {
+		return false
+	}
+
+	if kind == releaseStmt && name == s.skipped {
+		s.skipped = ""
+		return false
+	}
+
+	return true
+}
+
+func (s *savepoints) update(kind int, name string, results int) {
+	switch kind {
+	case savepointStmt:
+		s.names = append(s.names, name)
+		s.offsets = append(s.offsets, results)
+	case releaseStmt:
+		for i := len(s.names) - 1; i >= 0; i-- {
+			if s.names[i] == name {
+				s.names = s.names[:i]
+				s.offsets = s.offsets[:i]
+				return
+			}
+		}
+	}
+}
+
+func (s *savepoints) current() (string, int) {
+	if len(s.names) == 0 {
+		return "", 0
+	}
+
+	return s.names[len(s.names)-1], s.offsets[len(s.offsets)-1]
+}
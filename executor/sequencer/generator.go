@@ -0,0 +1,109 @@
+package sequencer
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/viant/datly/view/sequence"
+	"sync"
+	"time"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	snowflakeEpoch   = int64(1288834974657) //ms since unix epoch
+	snowflakeNodeBit = sequence.SnowflakeNodeBits
+	snowflakeSeqBit  = 12
+	maxSnowflakeNode = sequence.MaxSnowflakeNode
+	maxSnowflakeSeq  = int64(1)<<snowflakeSeqBit - 1
+)
+
+var snowflakes = &snowflakeRegistry{index: map[int64]*snowflake{}}
+
+type (
+	snowflake struct {
+		mux    sync.Mutex
+		node   int64
+		lastMs int64
+		seq    int64
+	}
+
+	snowflakeRegistry struct {
+		mux   sync.Mutex
+		index map[int64]*snowflake
+	}
+)
+
+func newUUID() (interface{}, error) {
+	return uuid.New().String(), nil
+}
+
+//newULID returns 26 characters Crockford's base32 encoded ULID, 48 bits of ms timestamp followed by 80 random bits
+func newULID() (interface{}, error) {
+	var data [16]byte
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		data[i] = byte(ms >> (40 - 8*i))
+	}
+
+	if _, err := rand.Read(data[6:]); err != nil {
+		return nil, fmt.Errorf("failed to generate ulid: %w", err)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < 8; i++ {
+		hi = hi<<8 | uint64(data[i])
+		lo = lo<<8 | uint64(data[i+8])
+	}
+
+	var result [26]byte
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = crockfordAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(result[:]), nil
+}
+
+func (r *snowflakeRegistry) node(node int64) *snowflake {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	generator, ok := r.index[node]
+	if !ok {
+		generator = &snowflake{node: node}
+		r.index[node] = generator
+	}
+
+	return generator
+}
+
+//next returns ms timestamp since snowflakeEpoch, followed by node and sequence within the same ms
+func (s *snowflake) next() (interface{}, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := s.now()
+	if now < s.lastMs { //clock moved backwards
+		now = s.lastMs
+	}
+
+	if now == s.lastMs {
+		s.seq = (s.seq + 1) & maxSnowflakeSeq
+		if s.seq == 0 {
+			for now <= s.lastMs {
+				now = s.now()
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+
+	s.lastMs = now
+	return (now-snowflakeEpoch)<<(snowflakeNodeBit+snowflakeSeqBit) | s.node<<snowflakeSeqBit | s.seq, nil
+}
+
+func (s *snowflake) now() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
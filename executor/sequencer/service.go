@@ -3,15 +3,21 @@ package sequencer
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"github.com/viant/datly/view/sequence"
 	"github.com/viant/sqlx/io/insert"
 	"github.com/viant/sqlx/option"
 	"strings"
 )
 
-type Service struct {
-	db  *sql.DB
-	ctx context.Context
-}
+type (
+	Service struct {
+		db      *sql.DB
+		tx      *sql.Tx
+		ctx     context.Context
+		configs []*sequence.Config
+	}
+)
 
 func (s *Service) Next(table string, any interface{}, selector string) error {
 	parts := strings.Split(selector, "/")
@@ -19,10 +25,29 @@ func (s *Service) Next(table string, any interface{}, selector string) error {
 	if err != nil {
 		return err
 	}
+
+	config := lookupConfig(s.configs, table)
+	switch config.Strategy {
+	case sequence.UUID:
+		return aWalker.Assign(any, newUUID)
+	case sequence.ULID:
+		return aWalker.Assign(any, newULID)
+	case sequence.Snowflake:
+		return aWalker.Assign(any, snowflakes.node(config.Node).next)
+	}
+
 	emptyRecordCount, err := aWalker.CountEmpty(any)
 	if err != nil || emptyRecordCount == 0 {
 		return err
 	}
+
+	switch config.Strategy {
+	case sequence.DBSequence:
+		return s.nextFromSequence(config, aWalker, any, emptyRecordCount)
+	case sequence.RowID:
+		return s.nextRowID(config, aWalker, any, emptyRecordCount)
+	}
+
 	record, err := aWalker.Leaf(any)
 	if err != nil {
 		return err
@@ -41,6 +66,87 @@ func (s *Service) Next(table string, any interface{}, selector string) error {
 	return err
 }
 
-func New(ctx context.Context, db *sql.DB) *Service {
-	return &Service{db: db, ctx: ctx}
+//nextFromSequence allocates IDs with Postgres sequence, values do not have to be contiguous
+func (s *Service) nextFromSequence(config *sequence.Config, aWalker *Walker, any interface{}, count int) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	rows, err := s.querier().QueryContext(s.ctx, fmt.Sprintf("SELECT nextval('%v') FROM generate_series(1, %v)", config.SequenceName(), count))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	values := make([]int64, 0, count)
+	for rows.Next() {
+		var value int64
+		if err = rows.Scan(&value); err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	return aWalker.Assign(any, func() (interface{}, error) {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("sequence %v returned less values than expected", config.SequenceName())
+		}
+
+		value := values[0]
+		values = values[1:]
+		return value, nil
+	})
+}
+
+//nextRowID allocates IDs following the max table rowid, or the last rowid reserved with the config if greater,
+//so that concurrent requests of one datly instance do not collide. IDs are allocated before insert, as nested relation
+//records reference them, thus the strategy is limited to single-writer deployments.
+func (s *Service) nextRowID(config *sequence.Config, aWalker *Walker, any interface{}, count int) error {
+	if !sequence.IsIdentifier(config.Table) {
+		return fmt.Errorf("invalid table name %v", config.Table)
+	}
+
+	var maxID int64
+	if err := s.querier().QueryRowContext(s.ctx, "SELECT COALESCE(MAX(rowid), 0) FROM "+config.Table).Scan(&maxID); err != nil {
+		return err
+	}
+
+	next := config.ReserveRowIDs(maxID, count)
+	return aWalker.Allocate(any, &Sequence{Value: next, IncrementBy: 1})
+}
+
+func (s *Service) querier() interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+} {
+	if s.tx != nil {
+		return s.tx
+	}
+
+	return s.db
+}
+
+//WithTx returns sequencer service reading database sequences and rowids within the tx
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	s.tx = tx
+	return s
+}
+
+func lookupConfig(configs []*sequence.Config, table string) *sequence.Config {
+	for _, config := range configs {
+		if strings.EqualFold(config.Table, table) {
+			return config
+		}
+	}
+
+	return &sequence.Config{Table: table, Strategy: sequence.AutoIncrement}
+}
+
+//New creates sequencer service, tables without config use AutoIncrement strategy
+func New(ctx context.Context, db *sql.DB, configs ...*sequence.Config) *Service {
+	return &Service{db: db, ctx: ctx, configs: configs}
 }
@@ -6,6 +6,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view/sequence"
 	_ "github.com/viant/sqlx/metadata/product/mysql"
 	_ "github.com/viant/sqlx/metadata/product/sqlite"
 	"os"
@@ -117,6 +118,150 @@ func TestService_Next(t *testing.T) {
 	}
 }
 
+func TestService_NextStrategy(t *testing.T) {
+	dsn := "/tmp/datly_sequnece_strategy_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	type Event struct {
+		ID   int64 `sqlx:"name=ID,primaryKey=true"`
+		Name string
+	}
+
+	type Doc struct {
+		ID   string `sqlx:"name=ID,primaryKey=true"`
+		Name string
+	}
+
+	var testCases = []struct {
+		description string
+		config      *sequence.Config
+		initSQL     []string
+		value       interface{}
+		expect      func(value interface{}) bool
+	}{
+		{
+			description: "rowid",
+			config:      &sequence.Config{Table: "EVENTS", Strategy: sequence.RowID},
+			initSQL: []string{
+				"DROP TABLE IF EXISTS EVENTS",
+				"CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT)",
+				"INSERT INTO EVENTS(ID, NAME) VALUES(10, 'xxx')",
+			},
+			value: []*Event{{Name: "abc"}, {ID: 3, Name: "def"}, {Name: "xyz"}},
+			expect: func(value interface{}) bool {
+				events := value.([]*Event)
+				return events[0].ID == 11 && events[1].ID == 3 && events[2].ID == 12
+			},
+		},
+		{
+			description: "uuid",
+			config:      &sequence.Config{Table: "DOCS", Strategy: sequence.UUID},
+			value:       []*Doc{{Name: "abc"}, {ID: "x", Name: "def"}},
+			expect: func(value interface{}) bool {
+				docs := value.([]*Doc)
+				return len(docs[0].ID) == 36 && docs[1].ID == "x"
+			},
+		},
+		{
+			description: "ulid",
+			config:      &sequence.Config{Table: "DOCS", Strategy: sequence.ULID},
+			value:       []*Doc{{Name: "abc"}, {Name: "def"}},
+			expect: func(value interface{}) bool {
+				docs := value.([]*Doc)
+				return len(docs[0].ID) == 26 && len(docs[1].ID) == 26 && docs[0].ID != docs[1].ID
+			},
+		},
+		{
+			description: "snowflake",
+			config:      &sequence.Config{Table: "EVENTS", Strategy: sequence.Snowflake, Node: 7},
+			value:       []*Event{{Name: "abc"}, {Name: "def"}},
+			expect: func(value interface{}) bool {
+				events := value.([]*Event)
+				return events[0].ID > 0 && events[1].ID > events[0].ID && (events[0].ID>>snowflakeSeqBit)&maxSnowflakeNode == 7
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, SQL := range testCase.initSQL {
+			_, err = db.Exec(SQL)
+			if !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+
+		if !assert.Nil(t, testCase.config.Validate(), testCase.description) {
+			continue
+		}
+
+		srv := New(context.Background(), db, testCase.config)
+		err = srv.Next(testCase.config.Table, testCase.value, "ID")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		assert.True(t, testCase.expect(testCase.value), testCase.description)
+	}
+}
+
+func TestService_NextRowIDReserved(t *testing.T) {
+	dsn := "/tmp/datly_sequnece_rowid_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	type Event struct {
+		ID   int64 `sqlx:"name=ID,primaryKey=true"`
+		Name string
+	}
+
+	for _, SQL := range []string{
+		"CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT)",
+		"INSERT INTO EVENTS(ID, NAME) VALUES(10, 'xxx')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	var testCases = []struct {
+		description string
+		value       []*Event
+		expect      []int64
+	}{
+		{description: "first allocation", value: []*Event{{Name: "abc"}, {Name: "def"}}, expect: []int64{11, 12}},
+		{description: "not inserted yet allocation", value: []*Event{{Name: "xyz"}}, expect: []int64{13}},
+	}
+
+	config := &sequence.Config{Table: "EVENTS", Strategy: sequence.RowID}
+	for _, testCase := range testCases {
+		tx, err := db.Begin()
+		if !assert.Nil(t, err, testCase.description) {
+			return
+		}
+
+		err = New(context.Background(), db, config).WithTx(tx).Next("EVENTS", testCase.value, "ID")
+		_ = tx.Rollback()
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		var actual []int64
+		for _, event := range testCase.value {
+			actual = append(actual, event.ID)
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
 func TestService_NextMySQL(t *testing.T) {
 	//os.Setenv("TEST_MYSQL_DSN", "root:dev@tcp(127.0.0.1)/dev")
 	dsn, skip := getTestConfig(t)
@@ -23,6 +23,69 @@ func (w *Walker) Allocate(value interface{}, seq *Sequence) error {
 	return w.allocate(w.root, value, seq)
 }
 
+//Assign assigns values returned by next to empty integer or string leaves, next returns int64 or string value
+func (w *Walker) Assign(value interface{}, next func() (interface{}, error)) error {
+	return w.assign(w.root, value, next)
+}
+
+func (w *Walker) assign(aNode *node, value interface{}, next func() (interface{}, error)) error {
+	ptr := xunsafe.AsPointer(value)
+	switch aNode.kind {
+	case nodeKindObject:
+		return w.assign(aNode.children, aNode.xField.Interface(ptr), next)
+	case nodeKindArray:
+		sliceLen := aNode.xSlice.Len(ptr)
+		for i := 0; i < sliceLen; i++ {
+			if err := w.assign(aNode.children, aNode.xSlice.ValuePointerAt(ptr, i), next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	item := aNode.xField.Addr(ptr)
+	if item == nil {
+		return fmt.Errorf("item was empty: %+v", aNode)
+	}
+
+	if strPtr, ok := stringPtr(item); ok {
+		if *strPtr != "" {
+			return nil
+		}
+
+		nextValue, err := next()
+		if err != nil {
+			return err
+		}
+
+		text, ok := nextValue.(string)
+		if !ok {
+			return fmt.Errorf("unable to assign %T to string key", nextValue)
+		}
+
+		*strPtr = text
+		return nil
+	}
+
+	intPtr, err := int64Ptr(item)
+	if err != nil || *intPtr != 0 {
+		return err
+	}
+
+	nextValue, err := next()
+	if err != nil {
+		return err
+	}
+
+	number, ok := nextValue.(int64)
+	if !ok {
+		return fmt.Errorf("unable to assign %T to integer key", nextValue)
+	}
+
+	*intPtr = number
+	return nil
+}
+
 func (w *Walker) allocate(aNode *node, value interface{}, seq *Sequence) error {
 	ptr := xunsafe.AsPointer(value)
 	var item interface{}
@@ -142,6 +205,21 @@ func int64Ptr(value interface{}) (*int64, error) {
 	}
 }
 
+func stringPtr(value interface{}) (*string, bool) {
+	switch actual := value.(type) {
+	case *string:
+		return actual, true
+	case **string:
+		if *actual == nil {
+			text := ""
+			*actual = &text
+		}
+		return *actual, true
+	}
+
+	return nil, false
+}
+
 func NewWalker(value interface{}, selectors []string) (*Walker, error) {
 	root, err := newNode(value, selectors...)
 	if err != nil {
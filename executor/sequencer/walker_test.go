@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 )
 
@@ -152,3 +153,64 @@ func TestWalker_Leaf(t *testing.T) {
 	}
 
 }
+
+func TestWalker_Assign(t *testing.T) {
+
+	type Foo struct {
+		ID   string
+		Name string
+	}
+
+	type Bar struct {
+		ID   int
+		Foos []*Foo
+	}
+
+	var testCases = []struct {
+		description string
+		value       interface{}
+		selectors   []string
+		textKeys    bool
+		expect      interface{}
+	}{
+		{
+			description: "nested string selector",
+			value: []*Bar{
+				{ID: 1, Foos: []*Foo{{ID: "a", Name: "abc1"}, {Name: "xyz1"}}},
+				{ID: 2, Foos: []*Foo{{Name: "xyz2"}}},
+			},
+			selectors: []string{"Foos", "ID"},
+			textKeys:  true,
+			expect: []*Bar{
+				{ID: 1, Foos: []*Foo{{ID: "a", Name: "abc1"}, {ID: "1", Name: "xyz1"}}},
+				{ID: 2, Foos: []*Foo{{ID: "2", Name: "xyz2"}}},
+			},
+		},
+		{
+			description: "integer selector",
+			value:       []*Bar{{ID: 5}, {}, {}},
+			selectors:   []string{"ID"},
+			expect:      []*Bar{{ID: 5}, {ID: 1}, {ID: 2}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		aWalker, err := NewWalker(testCase.value, testCase.selectors)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		counter := int64(0)
+		err = aWalker.Assign(testCase.value, func() (interface{}, error) {
+			counter++
+			if testCase.textKeys {
+				return strconv.Itoa(int(counter)), nil
+			}
+			return counter, nil
+		})
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, testCase.value, testCase.description)
+	}
+}
@@ -3,14 +3,39 @@ package executor
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/viant/datly/shared"
-	"strings"
+	"sort"
 	"sync"
 )
 
-type Executor struct {
-	sqlBuilder *SqlBuilder
+const (
+	SequentialMode Mode = "sequential"
+	ParallelMode   Mode = "parallel"
+)
+
+type (
+	//Mode controls how statements produced by the template are executed
+	//SequentialMode (default) runs statements in template order within one transaction and stops at the first failure
+	//ParallelMode runs each statement concurrently in its own transaction, use it only for independent statements,
+	//transactions are committed one by one, thus ParallelMode is not atomic: if commit fails, statements committed
+	//before are not rolled back and are reported with PartialCommitError
+	Mode string
+
+	Executor struct {
+		sqlBuilder *SqlBuilder
+	}
+)
+
+//Validate checks if Mode is supported
+func (m Mode) Validate() error {
+	switch m {
+	case "", SequentialMode, ParallelMode:
+		return nil
+	}
+
+	return fmt.Errorf("unsupported executor mode %v, supported: %v, %v", m, SequentialMode, ParallelMode)
 }
 
 func New() *Executor {
@@ -25,10 +50,16 @@ func (e *Executor) Exec(ctx context.Context, session *Session) error {
 		return err
 	}
 
+	if err = session.expectLocked(data); err != nil {
+		return err
+	}
+
 	if err = e.exec(ctx, session, data); err != nil {
 		return err
 	}
 
+	e.invalidateCache(ctx, session)
+	e.deliverEvents(ctx, session)
 	printer.Flush()
 	return err
 }
@@ -43,46 +74,168 @@ func (e *Executor) exec(ctx context.Context, session *Session, data []*SQLStatme
 		return err
 	}
 
-	tx, err := db.Begin()
+	ctx, cancel := session.txOptions().TxContext(ctx)
+	defer cancel()
+
+	if session.Mode == ParallelMode && !session.DryRun {
+		return e.execParallel(ctx, db, session, data)
+	}
+
+	return e.execSequential(ctx, db, session, data)
+}
+
+func (e *Executor) execSequential(ctx context.Context, db *sql.DB, session *Session, data []*SQLStatment) error {
+	tx, err := db.BeginTx(ctx, session.txOptions().Options())
 	if err != nil {
 		return err
 	}
 
-	errors := shared.NewErrors(0)
+	blocks := &savepoints{}
+	for i, stmt := range data {
+		if stmt.isEmpty() {
+			continue
+		}
+
+		kind, name := savepointKind(stmt.SQL)
+		if blocks.skip(kind, name) {
+			continue
+		}
+
+		result, err := e.executeStatement(ctx, db, tx, stmt, session)
+		if err != nil {
+			var conflictErr *ConflictError
+			if savepoint, offset := blocks.current(); kind == 0 && savepoint != "" && !errors.As(err, &conflictErr) {
+				if err = e.rollbackTo(ctx, db, tx, savepoint, session); err == nil {
+					blocks.skipped = savepoint
+					session.Results = session.Results[:offset]
+					continue
+				}
+			}
+
+			_ = tx.Rollback()
+			return NewStatementError(i, stmt, err)
+		}
+
+		blocks.update(kind, name, len(session.Results))
+		if kind == 0 {
+			session.addResult(i, result)
+		}
+	}
+
+	if session.DryRun {
+		return tx.Rollback()
+	}
+
+	return tx.Commit()
+}
+
+func (e *Executor) rollbackTo(ctx context.Context, db *sql.DB, tx *sql.Tx, savepoint string, session *Session) error {
+	_, err := e.executeStatement(ctx, db, tx, &SQLStatment{SQL: "ROLLBACK TO SAVEPOINT " + savepoint}, session)
+	return err
+}
+
+func (e *Executor) execParallel(ctx context.Context, db *sql.DB, session *Session, data []*SQLStatment) error {
+	for i, stmt := range data {
+		if kind, _ := savepointKind(stmt.SQL); kind != 0 {
+			return NewStatementError(i, stmt, fmt.Errorf("savepoints are not supported in %v mode", ParallelMode))
+		}
+	}
+
+	transactions := make([]*sql.Tx, len(data))
+	errors := shared.NewErrors(len(data))
 	wg := &sync.WaitGroup{}
-	wg.Add(len(data))
 	for i := range data {
-		e.execData(ctx, wg, tx, data[i], errors, session)
+		if data[i].isEmpty() {
+			continue
+		}
+
+		wg.Add(1)
+		go e.execData(ctx, wg, db, transactions, i, data[i], errors, session)
 	}
 
 	wg.Wait()
+	sort.Slice(session.Results, func(i, j int) bool {
+		return session.Results[i].Position < session.Results[j].Position
+	})
+
+	err := errors.Error()
+	var committed []int
+	for i, tx := range transactions {
+		if tx == nil {
+			continue
+		}
+
+		if err != nil {
+			_ = tx.Rollback()
+			continue
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = NewStatementError(i, data[i], err)
+			continue
+		}
+
+		committed = append(committed, i+1)
+	}
 
-	if err = errors.Error(); err != nil {
-		_ = tx.Rollback()
-		return err
+	if err != nil && len(committed) > 0 {
+		return &PartialCommitError{Committed: committed, Err: err}
 	}
 
-	return tx.Commit()
+	return err
 }
 
-func (e *Executor) execData(ctx context.Context, wg *sync.WaitGroup, tx *sql.Tx, data *SQLStatment, errors *shared.Errors, session *Session) {
+func (e *Executor) execData(ctx context.Context, wg *sync.WaitGroup, db *sql.DB, transactions []*sql.Tx, index int, data *SQLStatment, errors *shared.Errors, session *Session) {
 	defer wg.Done()
-	if strings.TrimSpace(data.SQL) == "" {
+	tx, err := db.BeginTx(ctx, session.txOptions().Options())
+	if err != nil {
+		errors.AddError(NewStatementError(index, data, err), index)
 		return
 	}
 
-	err := e.executeStatement(ctx, tx, data, session)
+	transactions[index] = tx
+	result, err := e.executeStatement(ctx, db, tx, data, session)
 	if err != nil {
-		errors.Append(err)
+		errors.AddError(NewStatementError(index, data, err), index)
+		return
 	}
+
+	session.addResult(index, result)
 }
 
-func (e *Executor) executeStatement(ctx context.Context, tx *sql.Tx, stmt *SQLStatment, session *Session) error {
-	_, err := tx.ExecContext(ctx, stmt.SQL, stmt.Args...)
+func (e *Executor) executeStatement(ctx context.Context, db *sql.DB, tx *sql.Tx, stmt *SQLStatment, session *Session) (*StatementResult, error) {
+	ctx, cancel := session.txOptions().StatementContext(ctx)
+	defer cancel()
+
+	//dry-run does not allocate IDs, as allocation can not be rolled back with the transaction
+	if stmt.bulk != nil && !session.DryRun {
+		if err := allocateIDs(ctx, session.View, db, tx, stmt.bulk); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &StatementResult{SQL: stmt.SQL, Args: stmt.Args}
+	var err error
+	if stmt.bulk != nil {
+		err = execBulkInsert(ctx, db, tx, stmt, session.BatchSize, result)
+	} else if hasReturning(stmt.SQL) {
+		err = queryReturning(ctx, tx, stmt, result)
+	} else {
+		err = execStatement(ctx, tx, stmt, result)
+	}
+
 	if err != nil {
 		session.View.Logger.LogDatabaseErr(stmt.SQL, err)
-		err = fmt.Errorf("error occured while connecting to database")
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("statement execution timeout exceeded")
+		}
+
+		return nil, fmt.Errorf("error occured while connecting to database")
 	}
 
-	return err
+	if stmt.lock != nil && result.RowsAffected == 0 {
+		return nil, &ConflictError{Column: stmt.lock.Column}
+	}
+
+	return result, nil
 }
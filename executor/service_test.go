@@ -0,0 +1,205 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"os"
+	"testing"
+)
+
+func TestHasReturning(t *testing.T) {
+	testcases := []struct {
+		description string
+		SQL         string
+		expect      bool
+	}{
+		{description: "insert", SQL: "INSERT INTO EVENTS(NAME) VALUES (?)"},
+		{description: "postgres returning", SQL: "INSERT INTO EVENTS(NAME) VALUES ($1) RETURNING ID", expect: true},
+		{description: "sql server output", SQL: "INSERT INTO EVENTS(NAME) OUTPUT INSERTED.ID VALUES (@p1)", expect: true},
+		{description: "quoted returning", SQL: "UPDATE EVENTS SET NAME = 'returning' WHERE ID = 1"},
+	}
+
+	for _, testcase := range testcases {
+		assert.Equal(t, testcase.expect, hasReturning(testcase.SQL), testcase.description)
+	}
+}
+
+func TestExecutor_Exec(t *testing.T) {
+	dsn := "/tmp/datly_executor_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	_, err = db.Exec("PRAGMA journal_mode=WAL")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	testcases := []struct {
+		description    string
+		mode           Mode
+		dryRun         bool
+		statements     []string
+		expectPosition int
+		expectCount    int
+		expectAffected []int64
+	}{
+		{
+			description: "sequential mode",
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"UPDATE EVENTS SET NAME = 'def' WHERE ID = 1",
+				"",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (2, 'xyz')",
+			},
+			expectCount:    2,
+			expectAffected: []int64{1, 1, 1},
+		},
+		{
+			description: "sequential mode stops at first failure",
+			mode:        SequentialMode,
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (2, 'xyz')",
+			},
+			expectPosition: 2,
+		},
+		{
+			description: "sequential mode rolls back failed savepoint block",
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"SAVEPOINT block1",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (2, 'def')",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (4, 'ghi')",
+				"RELEASE SAVEPOINT block1",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (3, 'xyz')",
+			},
+			expectCount:    2,
+			expectAffected: []int64{1, 1},
+		},
+		{
+			description: "dry run",
+			mode:        ParallelMode,
+			dryRun:      true,
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"INSERT INTO EVENTS(ID, NAME) VALUES (2, 'def')",
+				"UPDATE EVENTS SET NAME = 'xyz'",
+			},
+			expectAffected: []int64{1, 1, 2},
+		},
+		{
+			description: "parallel mode",
+			mode:        ParallelMode,
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"SELECT COUNT(*) FROM EVENTS",
+			},
+			expectCount: 1,
+		},
+		{
+			description: "parallel mode with savepoints",
+			mode:        ParallelMode,
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"SAVEPOINT block1",
+			},
+			expectPosition: 2,
+		},
+		{
+			description: "parallel mode rolls back on failure",
+			mode:        ParallelMode,
+			statements: []string{
+				"INSERT INTO EVENTS(ID, NAME) VALUES (1, 'abc')",
+				"INSERT INTO UNKNOWN_TABLE(ID) VALUES (1)",
+			},
+			expectPosition: 2,
+		},
+	}
+
+	for _, testcase := range testcases {
+		for _, SQL := range []string{"DROP TABLE IF EXISTS EVENTS", "CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT)"} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		var statements []*SQLStatment
+		for _, SQL := range testcase.statements {
+			statements = append(statements, &SQLStatment{SQL: SQL})
+		}
+
+		session := &Session{View: &view.View{}, Mode: testcase.mode, DryRun: testcase.dryRun}
+		if testcase.mode == ParallelMode && !testcase.dryRun {
+			err = New().execParallel(context.TODO(), db, session, statements)
+		} else {
+			err = New().execSequential(context.TODO(), db, session, statements)
+		}
+
+		if testcase.expectPosition != 0 {
+			stmtErr, ok := err.(*StatementError)
+			if assert.True(t, ok, testcase.description) {
+				assert.Equal(t, testcase.expectPosition, stmtErr.Position, testcase.description)
+			}
+		} else {
+			assert.Nil(t, err, testcase.description)
+		}
+
+		if testcase.expectAffected != nil {
+			var affected []int64
+			for _, result := range session.Results {
+				affected = append(affected, result.RowsAffected)
+			}
+			assert.Equal(t, testcase.expectAffected, affected, testcase.description)
+		}
+
+		count := 0
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM EVENTS").Scan(&count), testcase.description)
+		assert.Equal(t, testcase.expectCount, count, testcase.description)
+	}
+}
+
+func TestExecutor_ExecParallel_PartialCommit(t *testing.T) {
+	dsn := "/tmp/datly_executor_partial_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn+"?_foreign_keys=on")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	for _, SQL := range []string{
+		"CREATE TABLE PARENT (ID INTEGER PRIMARY KEY)",
+		"CREATE TABLE CHILD (ID INTEGER PRIMARY KEY, PARENT_ID INTEGER REFERENCES PARENT(ID) DEFERRABLE INITIALLY DEFERRED)",
+	} {
+		_, err = db.Exec(SQL)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	statements := []*SQLStatment{
+		{SQL: "SELECT COUNT(*) FROM PARENT"},
+		{SQL: "INSERT INTO CHILD(ID, PARENT_ID) VALUES (1, 99)"},
+	}
+
+	session := &Session{View: &view.View{}, Mode: ParallelMode}
+	err = New().execParallel(context.TODO(), db, session, statements)
+	partialErr, ok := err.(*PartialCommitError)
+	if !assert.True(t, ok, "expected partial commit error") {
+		return
+	}
+
+	assert.Equal(t, []int{1}, partialErr.Committed)
+	stmtErr, ok := partialErr.Err.(*StatementError)
+	if assert.True(t, ok) {
+		assert.Equal(t, 2, stmtErr.Position)
+	}
+}
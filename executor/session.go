@@ -1,6 +1,8 @@
 package executor
 
 import (
+	"fmt"
+	"github.com/viant/datly/executor/relay"
 	"github.com/viant/datly/view"
 	"github.com/viant/velty/est"
 	"sync"
@@ -11,6 +13,13 @@ type Session struct {
 	View       *view.View
 	mux        sync.Mutex
 	State      *est.State
+	Mode       Mode
+	TxOptions  *view.TxOptions
+	DryRun     bool               //executes statements and rolls back the transaction
+	Results    []*StatementResult //executed statements ordered by position
+	Expected   string             //optimistic lock expected value overriding value bound by the template, i.e. from If-Match header
+	BatchSize  int                //bulk insert batch size, defaults to 1000
+	Delivery   *relay.Delivery    //outbox events delivered after commit
 }
 
 func NewSession(selectors *view.Selectors, aView *view.View) (*Session, error) {
@@ -42,3 +51,66 @@ func (s *Session) Lookup(v *view.View) *view.ParamState {
 	state.Init(v)
 	return state
 }
+
+func (s *Session) txOptions() *view.TxOptions {
+	if s.TxOptions != nil {
+		return s.TxOptions
+	}
+
+	return s.View.TxOptions
+}
+
+//expectLocked overrides expected value of the locked statement, request is rejected unless exactly one statement is locked
+func (s *Session) expectLocked(statements []*SQLStatment) error {
+	if s.Expected == "" {
+		return nil
+	}
+
+	var locked *SQLStatment
+	for _, stmt := range statements {
+		if stmt.lock == nil {
+			continue
+		}
+
+		if locked != nil {
+			return fmt.Errorf("expected lock value can be used only with single locked statement")
+		}
+
+		locked = stmt
+	}
+
+	if locked == nil {
+		return fmt.Errorf("expected lock value was specified, but none of the statements was locked")
+	}
+
+	locked.Args[len(locked.Args)-1] = s.Expected
+	return nil
+}
+
+func (s *Session) addResult(index int, result *StatementResult) {
+	result.Position = index + 1
+	s.mux.Lock()
+	s.Results = append(s.Results, result)
+	s.mux.Unlock()
+}
+
+//Result returns executed statements summary
+func (s *Session) Result() *Result {
+	result := &Result{Statements: make([]*StatementResult, 0, len(s.Results)), Outbox: s.Delivery}
+	for _, stmtResult := range s.Results {
+		result.RowsAffected += stmtResult.RowsAffected
+		if stmtResult.LastInsertId != 0 {
+			result.LastInsertId = stmtResult.LastInsertId
+		}
+
+		result.Statements = append(result.Statements, &StatementResult{
+			Position:     stmtResult.Position,
+			RowsAffected: stmtResult.RowsAffected,
+			LastInsertId: stmtResult.LastInsertId,
+			Returning:    stmtResult.Returning,
+			Batches:      stmtResult.Batches,
+		})
+	}
+
+	return result
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	"github.com/viant/datly/executor/parser"
 	"github.com/viant/datly/logger"
+	"github.com/viant/datly/template/expand"
 	"github.com/viant/datly/view"
 	"github.com/viant/velty/est"
 	"strings"
@@ -14,9 +15,15 @@ type (
 	SQLStatment struct {
 		SQL  string
 		Args []interface{}
+		lock *view.OptimisticLock
+		bulk *expand.BulkInsert
 	}
 )
 
+func (s *SQLStatment) isEmpty() bool {
+	return strings.TrimSpace(s.SQL) == ""
+}
+
 func NewBuilder() *SqlBuilder {
 	return &SqlBuilder{}
 }
@@ -52,7 +59,17 @@ func (s *SqlBuilder) Build(aView *view.View, paramState *view.ParamState) (*est.
 		}
 	}
 
+	aDialect := aView.Dialect()
 	for _, data := range result {
+		if data.bulk, err = bulkInsert(data.SQL, params); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if data.bulk != nil {
+			data.Args = nil
+			continue
+		}
+
 		var placeholders []interface{}
 		expand, err := aView.Expand(&placeholders, data.SQL, &view.Selector{}, view.CriteriaParam{}, &view.BatchData{}, params)
 		if err != nil {
@@ -61,6 +78,9 @@ func (s *SqlBuilder) Build(aView *view.View, paramState *view.ParamState) (*est.
 
 		data.SQL = expand
 		data.Args = placeholders
+		lockStatement(data, aView.OptimisticLock(), aDialect)
+		data.SQL, data.Args = aDialect.InlineBools(data.SQL, data.Args)
+		data.SQL = aDialect.EnsurePlaceholders(data.SQL)
 	}
 
 	return state, result, printer, nil
@@ -0,0 +1,31 @@
+package invalidation
+
+import (
+	"context"
+	"github.com/viant/datly/view"
+)
+
+type Response struct {
+	Error  string   `json:"error,omitempty"`
+	Status string   `json:"status"`
+	Tags   []string `json:"tags"`
+	Purged int      `json:"purged"`
+}
+
+//Invalidate purges view and route caches tagged with any of the tags
+func Invalidate(ctx context.Context, tags ...string) *Response {
+	response := &Response{Status: "ok", Tags: tags}
+	if len(tags) == 0 {
+		response.Status = "error"
+		response.Error = "cache tags were empty"
+		return response
+	}
+
+	var err error
+	if response.Purged, err = view.InvalidateTags(ctx, tags...); err != nil {
+		response.Status = "error"
+		response.Error = err.Error()
+	}
+
+	return response
+}
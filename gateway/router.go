@@ -3,6 +3,7 @@ package gateway
 import (
 	"encoding/json"
 	furl "github.com/viant/afs/url"
+	"github.com/viant/datly/gateway/invalidation"
 	"github.com/viant/datly/gateway/runtime/meta"
 	"github.com/viant/datly/gateway/warmup"
 	"github.com/viant/datly/router"
@@ -98,6 +99,7 @@ func NewRouter(routersIndex map[string]*router.Router, config *Config, metrics *
 		metaConfig.OpenApiURI = router.AsRelative(metaConfig.OpenApiURI)
 		metaConfig.StatusURI = router.AsRelative(metaConfig.StatusURI)
 		metaConfig.CacheWarmURI = router.AsRelative(metaConfig.CacheWarmURI)
+		metaConfig.CacheInvalidateURI = router.AsRelative(metaConfig.CacheInvalidateURI)
 		metaConfig.ConfigURI = router.AsRelative(metaConfig.ConfigURI)
 	}
 
@@ -114,6 +116,7 @@ func NewRouter(routersIndex map[string]*router.Router, config *Config, metrics *
 			metaConfig.MetricURI,
 			metaConfig.StatusURI,
 			metaConfig.CacheWarmURI,
+			metaConfig.CacheInvalidateURI,
 			metaConfig.OpenApiURI,
 			metaConfig.ConfigURI,
 			config.APIPrefix,
@@ -209,6 +212,9 @@ func (r *Router) handle(writer http.ResponseWriter, request *http.Request) (int,
 		return http.StatusOK, nil
 	case r.metaConfig.OpenApiURI:
 		return r.matchByMultiRoutes(writer, request, viewPath)
+	case r.metaConfig.CacheInvalidateURI:
+		r.handleCacheInvalidate(writer, request, urlPath)
+		return http.StatusOK, nil
 	case r.metaConfig.StatusURI:
 		if r.statusHandler == nil {
 			return http.StatusNotFound, nil
@@ -464,6 +470,34 @@ func (r *Router) handleCacheWarmupWithErr(writer http.ResponseWriter, request *h
 	return http.StatusOK, nil
 }
 
+func (r *Router) handleCacheInvalidate(writer http.ResponseWriter, request *http.Request, urlPath string) {
+	statusCode, err := r.handleCacheInvalidateWithErr(writer, request, urlPath)
+	r.handleErrIfNeeded(writer, statusCode, err)
+}
+
+func (r *Router) handleCacheInvalidateWithErr(writer http.ResponseWriter, request *http.Request, urlPath string) (int, error) {
+	var tags []string
+	tagsPath := strings.TrimPrefix(router.AsRelative(urlPath), r.metaConfig.CacheInvalidateURI)
+	for _, tag := range strings.Split(tagsPath, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	response := invalidation.Invalidate(request.Context(), tags...)
+	data, err := json.Marshal(response)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if response.Status != "ok" {
+		writer.WriteHeader(http.StatusBadRequest)
+	}
+
+	writer.Write(data)
+	return http.StatusOK, nil
+}
+
 func (r *Router) ensureRequestURL(request *http.Request) error {
 	if request.URL != nil {
 		return nil
@@ -506,6 +540,24 @@ func (r *Router) PreCacheables(method string, uri string) ([]*view.View, error)
 	return r.extractCacheableViews(route)(method, uri)
 }
 
+//ScheduledWarmupViews returns routes views with scheduled cache warmup
+func (r *Router) ScheduledWarmupViews() []*view.View {
+	var result []*view.View
+	index := map[*view.View]bool{}
+	for _, route := range r.routes {
+		for _, aView := range router.ExtractCacheableViews(route) {
+			if aView.Cache.Warmup.Schedule == nil || index[aView] {
+				continue
+			}
+
+			index[aView] = true
+			result = append(result, aView)
+		}
+	}
+
+	return result
+}
+
 func (r *Router) availableRoutesErr(err error) error {
 	return &AvailableRoutesError{
 		Message: err.Error(),
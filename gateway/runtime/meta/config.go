@@ -13,18 +13,21 @@ const (
 	OpenApiURI = "/v1/api/meta/openapi/"
 	//CacheWarmupURI URIPrefix default value
 	CacheWarmupURI = "/v1/api/cache/warmup/"
+	//CacheInvalidateURI URIPrefix default value, followed by comma separated cache tags
+	CacheInvalidateURI = "/v1/api/cache/invalidate/"
 )
 
 // Config represents meta config
 type Config struct {
-	Version       string
-	MetricURI     string
-	ConfigURI     string
-	StatusURI     string
-	ViewURI       string
-	OpenApiURI    string
-	CacheWarmURI  string
-	AllowedSubnet []string
+	Version            string
+	MetricURI          string
+	ConfigURI          string
+	StatusURI          string
+	ViewURI            string
+	OpenApiURI         string
+	CacheWarmURI       string
+	CacheInvalidateURI string
+	AllowedSubnet      []string
 }
 
 // Init initialises config
@@ -51,4 +54,8 @@ func (m *Config) Init() {
 	if m.CacheWarmURI == "" {
 		m.CacheWarmURI = CacheWarmupURI
 	}
+
+	if m.CacheInvalidateURI == "" {
+		m.CacheInvalidateURI = CacheInvalidateURI
+	}
 }
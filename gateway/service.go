@@ -10,6 +10,7 @@ import (
 	furl "github.com/viant/afs/url"
 	"github.com/viant/cloudless/resource"
 	"github.com/viant/datly/auth/secret"
+	"github.com/viant/datly/gateway/warmup"
 	"github.com/viant/datly/router"
 	"github.com/viant/datly/shared"
 	"github.com/viant/datly/view"
@@ -39,6 +40,7 @@ type (
 		cancelFn             context.CancelFunc
 		session              *Session
 		JWTSigner            *signer.Service
+		scheduler            *warmup.Scheduler
 	}
 )
 
@@ -62,6 +64,10 @@ func (r *Service) Close() error {
 		r.cancelFn()
 	}
 
+	if r.scheduler != nil {
+		r.scheduler.Close()
+	}
+
 	return nil
 }
 
@@ -105,6 +111,7 @@ func New(ctx context.Context, config *Config, statusHandler http.Handler, author
 	}
 
 	err = srv.createRouterIfNeeded(ctx, metrics, statusHandler, authorizer)
+	srv.scheduleWarmup()
 	srv.detectChanges(metrics, statusHandler, authorizer)
 	fmt.Printf("initialised datly: %s\n", time.Now().Sub(start))
 	return srv, err
@@ -142,6 +149,10 @@ func (r *Service) createRouterIfNeeded(ctx context.Context, metrics *gmetric.Ser
 	r.session = nil
 	r.mux.Unlock()
 
+	if r.scheduler != nil {
+		r.scheduler.Start()
+	}
+
 	return nil
 }
 
@@ -404,6 +415,21 @@ func (r *Service) detectChanges(metrics *gmetric.Service, statusHandler http.Han
 	}()
 }
 
+//scheduleWarmup re-runs scheduled views cache warmup before warmed up entries expire
+//scheduler is started once any route view has Warmup.Schedule, reloaded routers start it if needed
+func (r *Service) scheduleWarmup() {
+	r.scheduler = warmup.NewScheduler(func() []*view.View {
+		aRouter, ok := r.Router()
+		if !ok {
+			return nil
+		}
+
+		return aRouter.ScheduledWarmupViews()
+	})
+
+	r.scheduler.Start()
+}
+
 func (r *Service) reloadFs() afs.Service {
 	if r.Config.UseCacheFS {
 		return r.cfs
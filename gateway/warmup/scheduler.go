@@ -0,0 +1,124 @@
+package warmup
+
+import (
+	"context"
+	"github.com/viant/datly/logger"
+	"github.com/viant/datly/view"
+	"github.com/viant/datly/warmup"
+	"sync"
+	"time"
+)
+
+const schedulerTick = time.Second
+
+type (
+	//ScheduledViews returns views with Warmup.Schedule
+	ScheduledViews func() []*view.View
+
+	//Scheduler re-runs views warmup every Warmup.Schedule interval, before warmed up entries expire
+	//each gateway instance runs its own Scheduler, instances do not coordinate scheduled runs
+	Scheduler struct {
+		lookup     ScheduledViews
+		watermarks *warmup.Watermarks
+		nextRun    map[string]time.Time
+		cancelFn   context.CancelFunc
+		mux        sync.Mutex
+		Now        func() time.Time
+		Logger     *logger.Adapter
+	}
+)
+
+//NewScheduler creates warmup Scheduler
+func NewScheduler(lookup ScheduledViews) *Scheduler {
+	return &Scheduler{
+		lookup:     lookup,
+		watermarks: warmup.NewWatermarks(),
+		nextRun:    map[string]time.Time{},
+		Now:        time.Now,
+		Logger:     logger.Default(),
+	}
+}
+
+//Start runs scheduler in the background until Close is called, scheduler is started only if any view has
+//Warmup.Schedule, Start can be called again once views are reloaded, and has no effect if scheduler is running
+//returns true if scheduler is running
+func (s *Scheduler) Start() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.cancelFn != nil {
+		return true
+	}
+
+	if len(s.lookup()) == 0 {
+		return false
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	s.cancelFn = cancelFn
+	go func() {
+		ticker := time.NewTicker(schedulerTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.RunDue(ctx); err != nil {
+					s.Logger.Log("error occurred while running scheduled warmup: %v\n", err.Error())
+				}
+			}
+		}
+	}()
+
+	return true
+}
+
+//Close stops scheduler
+func (s *Scheduler) Close() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.cancelFn != nil {
+		s.cancelFn()
+	}
+}
+
+//RunDue refreshes views which interval elapsed since the previous run, or since the view was first seen,
+//returns number of indexed entries
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	due := s.dueViews()
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	return warmup.Refresh(ctx, due, s.watermarks)
+}
+
+func (s *Scheduler) dueViews() []*view.View {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := s.Now()
+	nextRun := map[string]time.Time{}
+	var due []*view.View
+	for _, aView := range s.lookup() {
+		schedule := aView.Cache.Warmup.Schedule
+		key := aView.Name + "|" + aView.Cache.Provider + "|" + aView.Cache.Location
+		if _, ok := nextRun[key]; ok {
+			continue
+		}
+
+		next, ok := s.nextRun[key]
+		switch {
+		case !ok:
+			next = now.Add(schedule.Interval())
+		case !now.Before(next):
+			due = append(due, aView)
+			next = now.Add(schedule.Interval())
+		}
+
+		nextRun[key] = next
+	}
+
+	s.nextRun = nextRun
+	return due
+}
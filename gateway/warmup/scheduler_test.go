@@ -0,0 +1,107 @@
+package warmup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"testing"
+	"time"
+)
+
+func TestScheduler_DueViews(t *testing.T) {
+	newView := func(name string, intervalMs int) *view.View {
+		return &view.View{Name: name, Cache: &view.Cache{Location: name, Warmup: &view.Warmup{Schedule: &view.WarmupSchedule{IntervalMs: intervalMs}}}}
+	}
+
+	events := newView("events", 60000)
+	types := newView("types", 120000)
+	reloadedEvents := newView("events", 60000)
+
+	testcases := []struct {
+		description string
+		views       []*view.View
+		elapsed     time.Duration
+		expectDue   []string
+	}{
+		{
+			description: "first seen views",
+			views:       []*view.View{events, types},
+		},
+		{
+			description: "interval not elapsed",
+			views:       []*view.View{events, types},
+			elapsed:     30 * time.Second,
+		},
+		{
+			description: "events interval elapsed",
+			views:       []*view.View{events, types},
+			elapsed:     30 * time.Second,
+			expectDue:   []string{"events"},
+		},
+		{
+			description: "both intervals elapsed, reloaded view keeps schedule",
+			views:       []*view.View{reloadedEvents, types},
+			elapsed:     time.Minute,
+			expectDue:   []string{"events", "types"},
+		},
+		{
+			description: "removed view",
+			views:       []*view.View{reloadedEvents},
+			elapsed:     time.Minute,
+			expectDue:   []string{"events"},
+		},
+		{
+			description: "re-added view is first seen",
+			views:       []*view.View{reloadedEvents, types},
+			elapsed:     time.Minute,
+			expectDue:   []string{"events"},
+		},
+	}
+
+	var views []*view.View
+	now := time.Now()
+	scheduler := NewScheduler(func() []*view.View {
+		return views
+	})
+	scheduler.Now = func() time.Time {
+		return now
+	}
+
+	for _, testcase := range testcases {
+		views = testcase.views
+		now = now.Add(testcase.elapsed)
+
+		var actual []string
+		for _, aView := range scheduler.dueViews() {
+			actual = append(actual, aView.Name)
+		}
+
+		assert.Equal(t, testcase.expectDue, actual, testcase.description)
+	}
+}
+
+func TestScheduler_Start(t *testing.T) {
+	testcases := []struct {
+		description string
+		views       []*view.View
+		expect      bool
+	}{
+		{
+			description: "no scheduled views",
+		},
+		{
+			description: "scheduled views",
+			views:       []*view.View{{Name: "events", Cache: &view.Cache{Warmup: &view.Warmup{Schedule: &view.WarmupSchedule{IntervalMs: 60000}}}}},
+			expect:      true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		scheduler := NewScheduler(func() []*view.View {
+			return testcase.views
+		})
+
+		assert.Equal(t, testcase.expect, scheduler.Start(), testcase.description)
+		assert.Equal(t, testcase.expect, scheduler.Start(), testcase.description)
+		scheduler.Close()
+	}
+}
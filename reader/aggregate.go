@@ -0,0 +1,70 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/xunsafe"
+	"reflect"
+)
+
+//Aggregate reads grouped and aggregated Session.View data into Session.Dest, relations are not resolved.
+//Session.Dest has to be a pointer to the slice of View.AggregateType
+func (s *Service) Aggregate(ctx context.Context, session *Session) error {
+	destType := reflect.TypeOf(session.Dest)
+	if destType.Kind() != reflect.Ptr || destType.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("invalid aggregate destination type, expected pointer to slice but got %v", destType.String())
+	}
+
+	aView := session.View
+	selector := session.Selectors.Lookup(aView)
+	parentData, _ := session.ParentData()
+	matcher, err := s.sqlBuilder.AggregateSQL(aView, selector, &view.BatchData{}, parentData.AsParam())
+	if err != nil {
+		return err
+	}
+
+	db, err := aView.Db()
+	if err != nil {
+		return err
+	}
+
+	appender := xunsafe.NewSlice(destType.Elem()).Appender(xunsafe.AsPointer(session.Dest))
+	stats := s.NewStats(session, matcher, nil, nil)
+	start := Now()
+	reader, err := read.New(ctx, db, aView.Dialect().EnsurePlaceholders(matcher.SQL), func() interface{} {
+		return appender.Add()
+	})
+	if err != nil {
+		_, err = s.HandleSQLError(err, session, aView, matcher, stats)
+		return err
+	}
+
+	defer func() {
+		stmt := reader.Stmt()
+		if stmt == nil {
+			return
+		}
+
+		_ = stmt.Close()
+	}()
+
+	err = reader.QueryAll(ctx, func(row interface{}) error {
+		return nil
+	}, matcher.Args...)
+	if err != nil {
+		_, err = s.HandleSQLError(err, session, aView, matcher, stats)
+		return err
+	}
+
+	elapsed := Dif(Now(), start)
+	aView.Logger.Log("reading view %v aggregation took %v, SQL: %v , Args: %v\n", aView.Name, elapsed.String(), matcher.SQL, matcher.Args)
+	session.AddInfo(&Info{
+		View:     aView.Name,
+		Template: []*Stats{stats},
+		Elapsed:  elapsed.String(),
+	})
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/datly/view"
+)
+
+//Plan describes SQL built for the view without reading data
+type Plan struct {
+	View    string
+	SQL     string
+	Args    []interface{}            `json:",omitempty"`
+	Explain []map[string]interface{} `json:",omitempty"`
+}
+
+//Plan builds session main view SQL without reading data, with connector EXPLAIN output if explain is set
+func (s *Service) Plan(ctx context.Context, session *Session, explain bool) (*Plan, error) {
+	aView := session.View
+	selector := session.Selectors.Lookup(aView)
+	matcher, err := s.sqlBuilder.Build(aView, selector, &view.BatchData{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		View: aView.Name,
+		SQL:  aView.Dialect().EnsurePlaceholders(matcher.SQL),
+		Args: matcher.Args,
+	}
+
+	if !explain {
+		return plan, nil
+	}
+
+	plan.Explain, err = s.explain(ctx, aView, plan.SQL, plan.Args)
+	return plan, err
+}
+
+func (s *Service) explain(ctx context.Context, aView *view.View, SQL string, args []interface{}) ([]map[string]interface{}, error) {
+	explainSQL, ok := aView.Dialect().ExplainSQL(SQL)
+	if !ok {
+		return nil, fmt.Errorf("explain is not supported by %v dialect", aView.Dialect().Name)
+	}
+
+	db, err := aView.Db()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, explainSQL, args...)
+	if err != nil {
+		aView.Logger.LogDatabaseErr(explainSQL, err)
+		return nil, fmt.Errorf("failed to explain view %v SQL", aView.Name)
+	}
+
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if data, ok := values[i].([]byte); ok {
+				values[i] = string(data)
+			}
+
+			record[column] = values[i]
+		}
+
+		result = append(result, record)
+	}
+
+	return result, rows.Err()
+}
@@ -81,25 +81,12 @@ func (s *Service) readAll(ctx context.Context, session *Session, collector *view
 
 	aView := collector.View()
 	selector := session.Selectors.Lookup(aView)
-	collectorChildren, err := collector.Relations(selector)
+	relationGroup, err := s.readRelations(ctx, session, collector, wg, errorCollector)
 	if err != nil {
 		errorCollector.Append(err)
 		return
 	}
 
-	wg.Add(len(collectorChildren))
-	relationGroup := sync.WaitGroup{}
-	if !collector.SupportsParallel() {
-		relationGroup.Add(len(collectorChildren))
-	}
-
-	for i := range collectorChildren {
-		go func(i int, parent *view.View) {
-			defer s.afterRelationCompleted(wg, collector, &relationGroup)
-			s.readAll(ctx, session, collectorChildren[i], wg, errorCollector, aView)
-		}(i, aView)
-	}
-
 	collector.WaitIfNeeded()
 	if err := errorCollector.Error(); err != nil {
 		return
@@ -127,6 +114,35 @@ func (s *Service) readAll(ctx context.Context, session *Session, collector *view
 	collector.Fetched()
 
 	relationGroup.Wait()
+	s.onRelation(ctx, collector)
+}
+
+//readRelations reads collector relations in the background, if collector does not support parallel read,
+//returned group is done once all relations are read
+func (s *Service) readRelations(ctx context.Context, session *Session, collector *view.Collector, wg *sync.WaitGroup, errorCollector *shared.Errors) (*sync.WaitGroup, error) {
+	aView := collector.View()
+	collectorChildren, err := collector.Relations(session.Selectors.Lookup(aView))
+	if err != nil {
+		return nil, err
+	}
+
+	wg.Add(len(collectorChildren))
+	relationGroup := &sync.WaitGroup{}
+	if !collector.SupportsParallel() {
+		relationGroup.Add(len(collectorChildren))
+	}
+
+	for i := range collectorChildren {
+		go func(i int, parent *view.View) {
+			defer s.afterRelationCompleted(wg, collector, relationGroup)
+			s.readAll(ctx, session, collectorChildren[i], wg, errorCollector, aView)
+		}(i, aView)
+	}
+
+	return relationGroup, nil
+}
+
+func (s *Service) onRelation(ctx context.Context, collector *view.Collector) {
 	ptr, xslice := collector.Slice()
 	for i := 0; i < xslice.Len(ptr); i++ {
 		if actual, ok := xslice.ValuePointerAt(ptr, i).(OnRelationer); ok {
@@ -258,7 +274,7 @@ func (s *Service) queryMeta(ctx context.Context, session *Session, aView *view.V
 	slicePtr := unsafe.Pointer(slice.Pointer())
 	appender := aView.Template.Meta.Schema.Slice().Appender(slicePtr)
 
-	SQL := indexed.SQL
+	SQL := aView.Dialect().EnsurePlaceholders(indexed.SQL)
 	args := indexed.Args
 	now := Now()
 
@@ -330,6 +346,17 @@ func (s *Service) queryObjectsWithMeta(ctx context.Context, session *Session, aV
 		return err
 	}
 
+	if aView.IsCountEnabled() && collector.Relation() == nil {
+		countStats, err := s.queryCount(ctx, session, aView, selector, batchData, db, parentParam)
+		if countStats != nil {
+			info.Template = append(info.Template, countStats)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
 	var metaErr error
 	if aView.Template.Meta != nil {
 		wg.Add(1)
@@ -356,6 +383,25 @@ func (s *Service) queryObjectsWithMeta(ctx context.Context, session *Session, aV
 	return metaErr
 }
 
+func (s *Service) queryCount(ctx context.Context, session *Session, aView *view.View, selector *view.Selector, batchData *view.BatchData, db *sql.DB, parentParam *expand.MetaParam) (*Stats, error) {
+	matcher, err := s.sqlBuilder.CountSQL(aView, selector, batchData, nil, parentParam)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := s.NewStats(session, matcher, nil, nil)
+	begin := time.Now()
+	var totalCount int
+	err = db.QueryRowContext(ctx, aView.Dialect().EnsurePlaceholders(matcher.SQL), matcher.Args...).Scan(&totalCount)
+	aView.Logger.Log("reading view %v count took %v, SQL: %v , Args: %v\n", aView.Name, time.Since(begin).String(), matcher.SQL, matcher.Args)
+	if err != nil {
+		return s.HandleSQLError(err, session, aView, matcher, stats)
+	}
+
+	selector.SetTotalCount(totalCount)
+	return stats, nil
+}
+
 func (s *Service) queryObjects(ctx context.Context, session *Session, aView *view.View, selector *view.Selector, batchData *view.BatchData, db *sql.DB, collector *view.Collector, visitor view.VisitorFn) (*Stats, error) {
 	fullMatcher, columnInMatcher, err := s.getMatchers(aView, selector, batchData, collector, session)
 	if err != nil {
@@ -385,7 +431,7 @@ func (s *Service) queryObjects(ctx context.Context, session *Session, aView *vie
 
 	stats := s.NewStats(session, fullMatcher, cacheStats, err)
 
-	reader, err := read.New(ctx, db, fullMatcher.SQL, collector.NewItem(), options...)
+	reader, err := read.New(ctx, db, aView.Dialect().EnsurePlaceholders(fullMatcher.SQL), collector.NewItem(), options...)
 	if err != nil {
 		return s.HandleSQLError(err, session, aView, fullMatcher, stats)
 	}
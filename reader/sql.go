@@ -5,9 +5,9 @@ import (
 	"github.com/viant/datly/reader/metadata"
 	"github.com/viant/datly/template/expand"
 	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/dialect"
 	"github.com/viant/datly/view/keywords"
 	"github.com/viant/sqlx/io/read/cache"
-	"strconv"
 	"strings"
 )
 
@@ -16,13 +16,17 @@ const (
 	separatorFragment   = ", "
 	fromFragment        = " FROM "
 	asFragment          = " AS "
-	limitFragment       = " LIMIT "
 	orderByFragment     = " ORDER BY "
-	offsetFragment      = " OFFSET "
 	inFragment          = " IN ("
 	andFragment         = " AND ("
+	andOperatorFragment = " AND "
+	orFragment          = " OR "
 	placeholderFragment = "?"
 	encloseFragment     = ")"
+	countFragment       = "SELECT COUNT(*) AS TotalCount FROM ("
+	countAliasFragment  = ") t_count"
+	aggAliasFragment    = ") t_agg"
+	groupByFragment     = " GROUP BY "
 )
 
 type (
@@ -56,8 +60,19 @@ func (b *Builder) Build(aView *view.View, selector *view.Selector, batchData *vi
 		template = metadata.EnrichWithDiscover(template, true)
 	}
 
+	if !exclude.Pagination && len(selector.Cursor) > 0 {
+		if selector, err = b.withSeekCriteria(aView, selector); err != nil {
+			return nil, err
+		}
+	}
+
+	aDialect := aView.Dialect()
 	sb := strings.Builder{}
 	sb.WriteString(selectFragment)
+	if !exclude.Pagination {
+		sb.WriteString(aDialect.Top(actualLimit(aView, selector), selector.Offset))
+	}
+
 	if err = b.appendColumns(&sb, aView, selector); err != nil {
 		return nil, err
 	}
@@ -116,6 +131,12 @@ func (b *Builder) Build(aView *view.View, selector *view.Selector, batchData *vi
 		return nil, err
 	}
 
+	if !exclude.Pagination {
+		SQL = aDialect.WrapPagination(SQL, actualLimit(aView, selector), selector.Offset, b.resultColumns(aView, selector, relation)...)
+	}
+
+	SQL, placeholders = aDialect.InlineBools(SQL, placeholders)
+
 	matcher := &cache.ParmetrizedQuery{
 		SQL:  SQL,
 		Args: placeholders,
@@ -155,12 +176,52 @@ func (b *Builder) appendSelectorColumns(sb *strings.Builder, view *view.View, se
 		}
 
 		sb.WriteString(" ")
-		sb.WriteString(viewColumn.SqlExpression())
+		sb.WriteString(b.columnExpression(view, viewColumn))
 	}
 
 	return nil
 }
 
+func (b *Builder) columnExpression(aView *view.View, column *view.Column) string {
+	if column.Name != column.SqlExpression() {
+		return column.SqlExpression()
+	}
+
+	return aView.Dialect().Quote(column.Name)
+}
+
+//resultColumns returns names of the columns selected by Build
+func (b *Builder) resultColumns(aView *view.View, selector *view.Selector, relation *view.Relation) []string {
+	aDialect := aView.Dialect()
+	var result []string
+	if len(selector.Columns) == 0 {
+		for _, column := range aView.Columns {
+			result = append(result, aDialect.Quote(column.Name))
+		}
+	}
+
+	for _, name := range selector.Columns {
+		if column, ok := aView.ColumnByName(name); ok {
+			result = append(result, aDialect.Quote(column.Name))
+		}
+	}
+
+	if relation == nil || aView.Template.IsActualTemplate() {
+		return result
+	}
+
+	_, ok := aView.ColumnByName(relation.Of.Column)
+	if len(selector.Columns) > 0 {
+		ok = selector.Has(relation.Of.Column)
+	}
+
+	if !ok {
+		result = append(result, aDialect.Quote(relation.Of.Column))
+	}
+
+	return result
+}
+
 func (b *Builder) viewAlias(view *view.View) string {
 	var alias string
 	if view.Alias != "" {
@@ -182,7 +243,7 @@ func (b *Builder) appendViewColumns(sb *strings.Builder, view *view.View) {
 			sb.WriteString(alias)
 		}
 
-		sb.WriteString(column.SqlExpression())
+		sb.WriteString(b.columnExpression(view, column))
 	}
 }
 
@@ -195,38 +256,25 @@ func (b *Builder) appendViewAlias(sb *strings.Builder, view *view.View) {
 	sb.WriteString(view.Alias)
 }
 
-func (b *Builder) updatePagination(params *view.CriteriaParam, view *view.View, selector *view.Selector, exclude *Exclude) error {
+func (b *Builder) updatePagination(params *view.CriteriaParam, aView *view.View, selector *view.Selector, exclude *Exclude) error {
 	if exclude.Pagination {
 		return nil
 	}
 
 	sb := strings.Builder{}
-	if err := b.appendOrderBy(&sb, view, selector); err != nil {
+	if err := b.appendOrderBy(&sb, aView, selector); err != nil {
 		return err
 	}
-	b.appendLimit(&sb, view, selector)
-	b.appendOffset(&sb, selector)
-	params.Pagination = sb.String()
-	return nil
-}
 
-func (b *Builder) appendLimit(sb *strings.Builder, aView *view.View, selector *view.Selector) {
+	aDialect := aView.Dialect()
 	limit := actualLimit(aView, selector)
-	if limit == 0 {
-		return
+	if sb.Len() == 0 && aDialect.NeedsOrderBy(limit, selector.Offset) {
+		sb.WriteString(aDialect.DefaultOrderBy())
 	}
 
-	sb.WriteString(limitFragment)
-	sb.WriteString(strconv.Itoa(limit))
-}
-
-func (b *Builder) appendOffset(sb *strings.Builder, selector *view.Selector) {
-	if selector.Offset == 0 {
-		return
-	}
-
-	sb.WriteString(offsetFragment)
-	sb.WriteString(strconv.Itoa(selector.Offset))
+	sb.WriteString(aDialect.Paginate(limit, selector.Offset))
+	params.Pagination = sb.String()
+	return nil
 }
 
 func (b *Builder) updateCriteria(params *view.CriteriaParam, columnsInMeta *reservedMeta) error {
@@ -276,7 +324,7 @@ func (b *Builder) updateColumnsIn(params *view.CriteriaParam, view *view.View, r
 	sb := strings.Builder{}
 	sb.WriteString(" ")
 	sb.WriteString(alias)
-	sb.WriteString(batchData.ColumnName)
+	sb.WriteString(view.Dialect().Quote(batchData.ColumnName))
 	sb.WriteString(inFragment)
 
 	for i := range batchData.ValuesBatch {
@@ -290,6 +338,10 @@ func (b *Builder) updateColumnsIn(params *view.CriteriaParam, view *view.View, r
 }
 
 func (b *Builder) appendOrderBy(sb *strings.Builder, view *view.View, selector *view.Selector) error {
+	if view.HasCursorTiebreaker() && (view.Selector.Constraints.Cursor || len(selector.Cursor) > 0) {
+		return b.appendSortColumns(sb, view, selector)
+	}
+
 	if selector.OrderBy != "" {
 		col, ok := view.ColumnByName(selector.OrderBy)
 		if !ok {
@@ -297,7 +349,7 @@ func (b *Builder) appendOrderBy(sb *strings.Builder, view *view.View, selector *
 		}
 
 		sb.WriteString(orderByFragment)
-		sb.WriteString(col.Name)
+		sb.WriteString(view.Dialect().Quote(col.Name))
 		return nil
 	}
 
@@ -310,6 +362,28 @@ func (b *Builder) appendOrderBy(sb *strings.Builder, view *view.View, selector *
 	return nil
 }
 
+//appendSortColumns appends cursor pagination ORDER BY, ended with the primary key tiebreaker
+func (b *Builder) appendSortColumns(sb *strings.Builder, aView *view.View, selector *view.Selector) error {
+	sortColumns, err := aView.SortColumns(selector)
+	if err != nil {
+		return err
+	}
+
+	sb.WriteString(orderByFragment)
+	for i, sortColumn := range sortColumns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(aView.Dialect().Quote(sortColumn.Column.Name))
+		if sortColumn.Desc {
+			sb.WriteString(" DESC")
+		}
+	}
+
+	return nil
+}
+
 func (b *Builder) appendRelationColumn(sb *strings.Builder, aView *view.View, selector *view.Selector, relation *view.Relation) error {
 	if relation == nil {
 		return nil
@@ -357,6 +431,89 @@ func (b *Builder) checkSelectorAndAppendRelColumn(sb *strings.Builder, aView *vi
 	return nil
 }
 
+func (b *Builder) withSeekCriteria(aView *view.View, selector *view.Selector) (*view.Selector, error) {
+	sortColumns, err := aView.SortColumns(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sortColumns) != len(selector.Cursor) {
+		return nil, fmt.Errorf("cursor does not match view %v order", aView.Name)
+	}
+
+	aDialect := aView.Dialect()
+	var placeholders []interface{}
+	sb := strings.Builder{}
+	disjuncts := 0
+	for i, sortColumn := range sortColumns {
+		after, afterArgs, ok := b.seekAfter(aDialect, sortColumn, selector.Cursor[i])
+		if !ok {
+			continue
+		}
+
+		if disjuncts != 0 {
+			sb.WriteString(orFragment)
+		}
+
+		disjuncts++
+		sb.WriteString("(")
+		for j := 0; j < i; j++ {
+			column := aDialect.Quote(sortColumns[j].Column.Name)
+			if selector.Cursor[j] == nil {
+				sb.WriteString(column + " IS NULL")
+			} else {
+				sb.WriteString(column + " = ?")
+				placeholders = append(placeholders, selector.Cursor[j])
+			}
+			sb.WriteString(andOperatorFragment)
+		}
+
+		sb.WriteString(after)
+		placeholders = append(placeholders, afterArgs...)
+		sb.WriteString(encloseFragment)
+	}
+
+	if disjuncts == 0 {
+		sb.WriteString(aDialect.Predicate(false))
+	}
+
+	selectorCopy := *selector
+	if strings.TrimSpace(selectorCopy.Criteria) == "" {
+		selectorCopy.Criteria = sb.String()
+		selectorCopy.Placeholders = placeholders
+		return &selectorCopy, nil
+	}
+
+	selectorCopy.Criteria = "(" + selectorCopy.Criteria + ")" + andFragment + sb.String() + encloseFragment
+	selectorCopy.Placeholders = append(append([]interface{}{}, selector.Placeholders...), placeholders...)
+	return &selectorCopy, nil
+}
+
+//seekAfter returns predicate matching column values sorted after the cursor value, false if there is no such value
+//NULL values are sorted as the dialect does: before other values in ascending order if Dialect.NullsFirst, after otherwise
+func (b *Builder) seekAfter(aDialect *dialect.Dialect, sortColumn *view.SortColumn, value interface{}) (string, []interface{}, bool) {
+	column := aDialect.Quote(sortColumn.Column.Name)
+	nullsBefore := aDialect.NullsFirst != sortColumn.Desc
+	if value == nil {
+		if nullsBefore {
+			return column + " IS NOT NULL", nil, true
+		}
+
+		return "", nil, false
+	}
+
+	operator := " > ?"
+	if sortColumn.Desc {
+		operator = " < ?"
+	}
+
+	if nullsBefore || !sortColumn.Column.Nullable {
+		return column + operator, []interface{}{value}, true
+	}
+
+	return "(" + column + operator + orFragment + column + " IS NULL)", []interface{}{value}, true
+}
+
 func actualLimit(aView *view.View, selector *view.Selector) int {
 	if selector.Limit != 0 {
 		return selector.Limit
@@ -371,6 +528,92 @@ func (b *Builder) ExactMetaSQL(aView *view.View, selector *view.Selector, batchD
 	}, parent, nil)
 }
 
+//CountSQL builds SQL counting all records matching View and Selector criteria, regardless of pagination
+func (b *Builder) CountSQL(aView *view.View, selector *view.Selector, batchData *view.BatchData, relation *view.Relation, parent *expand.MetaParam) (*cache.ParmetrizedQuery, error) {
+	matcher, err := b.Build(aView, selector, batchData, relation, &Exclude{
+		Pagination: true,
+	}, parent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher.SQL = countFragment + matcher.SQL + countAliasFragment
+	return matcher, nil
+}
+
+//AggregateSQL builds SQL grouping and aggregating records matching View and Selector criteria
+func (b *Builder) AggregateSQL(aView *view.View, selector *view.Selector, batchData *view.BatchData, parent *expand.MetaParam) (*cache.ParmetrizedQuery, error) {
+	matcher, err := b.Build(aView, selector, batchData, nil, &Exclude{
+		Pagination: true,
+	}, parent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	aDialect := aView.Dialect()
+	limit := actualLimit(aView, selector)
+	sb := strings.Builder{}
+	sb.WriteString(selectFragment)
+	sb.WriteString(aDialect.Top(limit, selector.Offset))
+	for i, column := range selector.GroupBy {
+		if i != 0 {
+			sb.WriteString(separatorFragment)
+		}
+		sb.WriteString(aDialect.Quote(column))
+	}
+
+	for i, aggregate := range selector.Aggregates {
+		if i != 0 || len(selector.GroupBy) > 0 {
+			sb.WriteString(separatorFragment)
+		}
+
+		sb.WriteString(strings.ToUpper(aggregate.Func))
+		sb.WriteString("(")
+		if aggregate.Column == "" {
+			sb.WriteString("*")
+		} else {
+			sb.WriteString(aDialect.Quote(aggregate.Column))
+		}
+		sb.WriteString(encloseFragment)
+		sb.WriteString(asFragment)
+		sb.WriteString(aggregate.Name)
+	}
+
+	sb.WriteString(fromFragment)
+	sb.WriteString("(")
+	sb.WriteString(matcher.SQL)
+	sb.WriteString(aggAliasFragment)
+
+	for i, column := range selector.GroupBy {
+		if i == 0 {
+			sb.WriteString(groupByFragment)
+		} else {
+			sb.WriteString(separatorFragment)
+		}
+		sb.WriteString(aDialect.Quote(column))
+	}
+
+	if selector.OrderBy != "" {
+		sb.WriteString(orderByFragment)
+		sb.WriteString(aDialect.Quote(selector.OrderBy))
+	} else if aDialect.NeedsOrderBy(limit, selector.Offset) {
+		sb.WriteString(aDialect.DefaultOrderBy())
+	}
+
+	sb.WriteString(aDialect.Paginate(limit, selector.Offset))
+	var columns []string
+	for _, column := range selector.GroupBy {
+		columns = append(columns, aDialect.Quote(column))
+	}
+
+	for _, aggregate := range selector.Aggregates {
+		columns = append(columns, aggregate.Name)
+	}
+
+	matcher.SQL = aDialect.WrapPagination(sb.String(), limit, selector.Offset, columns...)
+	return matcher, nil
+}
+
 func (b *Builder) CacheMetaSQL(aView *view.View, selector *view.Selector, batchData *view.BatchData, relation *view.Relation, parent *expand.MetaParam) (*cache.ParmetrizedQuery, error) {
 	return b.metaSQL(aView, selector, batchData, relation, &Exclude{Pagination: true, ColumnsIn: true}, parent, &expand.MockExpander{})
 }
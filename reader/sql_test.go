@@ -2,13 +2,16 @@ package reader
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/assertly"
 	"github.com/viant/datly/internal/tests"
 	"github.com/viant/datly/view"
+	"github.com/viant/datly/view/dialect"
 	"github.com/viant/dsunit"
 	"github.com/viant/toolbox"
+	"os"
 	"path"
 	"reflect"
 	"strings"
@@ -376,6 +379,74 @@ func TestBuilder_Build(t *testing.T) {
 				},
 			},
 		},
+		{
+			dataset:      "dataset001_events/",
+			description:  `select statement | boolean criteria`,
+			output:       `SELECT  t.ID,  t.Price FROM events AS t   WHERE Price > ? AND (ID > 0) = 1`,
+			placeholders: []interface{}{10},
+			view: &view.View{
+				Columns: []*view.Column{
+					{
+						Name:     "ID",
+						DataType: "Int",
+					},
+					{
+						Name:     "Price",
+						DataType: "Float",
+					},
+				},
+				Name:  "events",
+				Table: "events",
+				Template: &view.Template{
+					Schema:         view.NewSchema(reflect.TypeOf(Params{})),
+					PresenceSchema: view.NewSchema(reflect.TypeOf(PresenceMap{})),
+				},
+			},
+			selector: &view.Selector{
+				Criteria:     "Price > ? AND (ID > 0) = ?",
+				Placeholders: []interface{}{10, true},
+				Parameters: view.ParamState{
+					Values: Params{},
+					Has:    PresenceMap{},
+				},
+			},
+		},
+		{
+			dataset:      "dataset001_events/",
+			description:  `select statement | cursor`,
+			output:       `SELECT  t.ID,  t.Price FROM events AS t   WHERE (ID > ?)   ORDER BY ID LIMIT 10`,
+			placeholders: []interface{}{5},
+			view: &view.View{
+				Columns: []*view.Column{
+					{
+						Name:     "ID",
+						DataType: "Int",
+					},
+					{
+						Name:     "Price",
+						DataType: "Float",
+					},
+				},
+				Name:  "events",
+				Table: "events",
+				Selector: &view.Config{
+					OrderBy: "ID",
+					Limit:   10,
+				},
+				Template: &view.Template{
+					Schema:         view.NewSchema(reflect.TypeOf(Params{})),
+					PresenceSchema: view.NewSchema(reflect.TypeOf(PresenceMap{})),
+				},
+			},
+			batchData: &view.BatchData{},
+			selector: &view.Selector{
+				Cursor: []interface{}{5},
+				Parameters: view.ParamState{
+					Values: Params{},
+					Has:    PresenceMap{},
+				},
+			},
+		},
 	}
 
 	//for index, useCase := range useCases[len(useCases)-1:] {
@@ -407,6 +478,187 @@ func TestBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestBuilder_CountSQL(t *testing.T) {
+	testLocation := toolbox.CallerDirectory(3)
+
+	type Params struct{}
+	type PresenceMap struct{}
+
+	useCases := []struct {
+		view         *view.View
+		selector     *view.Selector
+		placeholders []interface{}
+		description  string
+		output       string
+		dataset      string
+	}{
+		{
+			dataset:      "dataset001_events/",
+			description:  `count | ignores pagination and cursor`,
+			output:       `SELECT COUNT(*) AS TotalCount FROM (SELECT  t.ID,  t.Price FROM events AS t   WHERE Price > ? ) t_count`,
+			placeholders: []interface{}{10},
+			view: &view.View{
+				Columns: []*view.Column{
+					{
+						Name:     "ID",
+						DataType: "Int",
+					},
+					{
+						Name:     "Price",
+						DataType: "Float",
+					},
+				},
+				Name:  "events",
+				Table: "events",
+				Selector: &view.Config{
+					OrderBy: "ID",
+					Limit:   10,
+					Constraints: &view.Constraints{
+						Count: true,
+					},
+				},
+				Template: &view.Template{
+					Schema:         view.NewSchema(reflect.TypeOf(Params{})),
+					PresenceSchema: view.NewSchema(reflect.TypeOf(PresenceMap{})),
+				},
+			},
+			selector: &view.Selector{
+				Criteria:     "Price > ?",
+				Placeholders: []interface{}{10},
+				Cursor:       []interface{}{5},
+				Offset:       20,
+				Parameters: view.ParamState{
+					Values: Params{},
+					Has:    PresenceMap{},
+				},
+			},
+		},
+	}
+
+	for index, useCase := range useCases {
+		tests.LogHeader(fmt.Sprintf("Running testcase nr: %v | %v \n", index, useCase.description))
+		resourcePath := path.Join(testLocation, "testdata", "datasets", useCase.dataset, "populate")
+		if initDb(t, path.Join(testLocation, "testdata", "db_config.yaml"), resourcePath, "db") {
+			return
+		}
+
+		useCase.view.Connector = &view.Connector{
+			Name:   "db",
+			DSN:    "./testdata/db/db.db",
+			Driver: "sqlite3",
+		}
+
+		if !assert.Nil(t, useCase.view.Init(context.TODO(), view.EmptyResource()), useCase.description) {
+			continue
+		}
+
+		useCase.selector.Init()
+		matcher, err := NewBuilder().CountSQL(useCase.view, useCase.selector, &view.BatchData{}, nil, nil)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+
+		assertly.AssertValues(t, useCase.placeholders, matcher.Args, useCase.description)
+		assert.Equal(t, useCase.output, strings.TrimSpace(matcher.SQL), useCase.description)
+	}
+}
+
+func TestBuilder_AggregateSQL(t *testing.T) {
+	testLocation := toolbox.CallerDirectory(3)
+
+	type Params struct{}
+	type PresenceMap struct{}
+
+	useCases := []struct {
+		selector     *view.Selector
+		placeholders []interface{}
+		description  string
+		output       string
+		dataset      string
+	}{
+		{
+			dataset:     "dataset001_events/",
+			description: `aggregate | no group by`,
+			output:      `SELECT COUNT(*) AS Count, SUM(Price) AS SumPrice FROM (SELECT  t.ID,  t.Price FROM events AS t  ) t_agg LIMIT 10`,
+			selector: &view.Selector{
+				Aggregates: []*view.Aggregate{
+					{Func: view.CountFunc, Name: "Count"},
+					{Func: view.SumFunc, Column: "Price", Name: "SumPrice"},
+				},
+			},
+		},
+		{
+			dataset:      "dataset001_events/",
+			description:  `aggregate | group by with criteria and order by`,
+			output:       `SELECT ID, MAX(Price) AS MaxPrice FROM (SELECT  t.ID,  t.Price FROM events AS t   WHERE Price > ? ) t_agg GROUP BY ID ORDER BY ID LIMIT 5`,
+			placeholders: []interface{}{10},
+			selector: &view.Selector{
+				Criteria:     "Price > ?",
+				Placeholders: []interface{}{10},
+				OrderBy:      "ID",
+				Limit:        5,
+				GroupBy:      []string{"ID"},
+				Aggregates: []*view.Aggregate{
+					{Func: view.MaxFunc, Column: "Price", Name: "MaxPrice"},
+				},
+			},
+		},
+	}
+
+	for index, useCase := range useCases {
+		tests.LogHeader(fmt.Sprintf("Running testcase nr: %v | %v \n", index, useCase.description))
+		resourcePath := path.Join(testLocation, "testdata", "datasets", useCase.dataset, "populate")
+		if initDb(t, path.Join(testLocation, "testdata", "db_config.yaml"), resourcePath, "db") {
+			return
+		}
+
+		aView := &view.View{
+			Columns: []*view.Column{
+				{
+					Name:     "ID",
+					DataType: "Int",
+				},
+				{
+					Name:     "Price",
+					DataType: "Float",
+				},
+			},
+			Name:  "events",
+			Table: "events",
+			Selector: &view.Config{
+				OrderBy: "ID",
+				Limit:   10,
+				Constraints: &view.Constraints{
+					Aggregation: &view.Aggregation{GroupBy: []string{"*"}},
+				},
+			},
+			Template: &view.Template{
+				Schema:         view.NewSchema(reflect.TypeOf(Params{})),
+				PresenceSchema: view.NewSchema(reflect.TypeOf(PresenceMap{})),
+			},
+			Connector: &view.Connector{
+				Name:   "db",
+				DSN:    "./testdata/db/db.db",
+				Driver: "sqlite3",
+			},
+		}
+
+		if !assert.Nil(t, aView.Init(context.TODO(), view.EmptyResource()), useCase.description) {
+			continue
+		}
+
+		useCase.selector.Parameters = view.ParamState{Values: Params{}, Has: PresenceMap{}}
+		useCase.selector.Init()
+		matcher, err := NewBuilder().AggregateSQL(aView, useCase.selector, &view.BatchData{}, nil)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+
+		assertly.AssertValues(t, useCase.placeholders, matcher.Args, useCase.description)
+		assert.Equal(t, useCase.output, strings.TrimSpace(matcher.SQL), useCase.description)
+	}
+}
+
 func initDb(t *testing.T, configPath, datasetPath, dataStore string) bool {
 	datasetPath = datasetPath + "_" + dataStore
 	if !dsunit.InitFromURL(t, configPath) {
@@ -421,3 +673,169 @@ func initDb(t *testing.T, configPath, datasetPath, dataStore string) bool {
 
 	return false
 }
+
+func TestBuilder_SeekAfter(t *testing.T) {
+	useCases := []struct {
+		description string
+		dialect     *dialect.Dialect
+		column      *view.Column
+		desc        bool
+		value       interface{}
+		expect      string
+		expectArgs  []interface{}
+		expectNone  bool
+	}{
+		{
+			description: "not nullable column",
+			dialect:     dialect.PostgreSQL,
+			column:      &view.Column{Name: "ID"},
+			value:       5,
+			expect:      "ID > ?",
+			expectArgs:  []interface{}{5},
+		},
+		{
+			description: "nullable column, nulls sorted last",
+			dialect:     dialect.PostgreSQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			value:       5,
+			expect:      "(Price > ? OR Price IS NULL)",
+			expectArgs:  []interface{}{5},
+		},
+		{
+			description: "nullable column, nulls sorted first",
+			dialect:     dialect.MySQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			value:       5,
+			expect:      "Price > ?",
+			expectArgs:  []interface{}{5},
+		},
+		{
+			description: "null cursor value, nulls sorted first",
+			dialect:     dialect.MySQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			expect:      "Price IS NOT NULL",
+		},
+		{
+			description: "null cursor value, nulls sorted last",
+			dialect:     dialect.PostgreSQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			expectNone:  true,
+		},
+		{
+			description: "descending null cursor value, nulls sorted last",
+			dialect:     dialect.MySQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			desc:        true,
+			expectNone:  true,
+		},
+		{
+			description: "descending nullable column, nulls sorted first",
+			dialect:     dialect.MySQL,
+			column:      &view.Column{Name: "Price", Nullable: true},
+			desc:        true,
+			value:       5,
+			expect:      "(Price < ? OR Price IS NULL)",
+			expectArgs:  []interface{}{5},
+		},
+	}
+
+	for _, useCase := range useCases {
+		SQL, args, ok := NewBuilder().seekAfter(useCase.dialect, &view.SortColumn{Column: useCase.column, Desc: useCase.desc}, useCase.value)
+		assert.Equal(t, !useCase.expectNone, ok, useCase.description)
+		assert.Equal(t, useCase.expect, SQL, useCase.description)
+		assert.Equal(t, useCase.expectArgs, args, useCase.description)
+	}
+}
+
+func TestBuilder_CursorPages(t *testing.T) {
+	type Params struct{}
+	type PresenceMap struct{}
+
+	dsn := "/tmp/datly_cursor_pages_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	for _, SQL := range []string{
+		"CREATE TABLE items (ID INTEGER PRIMARY KEY, PRICE INTEGER)",
+		"INSERT INTO items (ID, PRICE) VALUES (1, 10), (2, 10), (3, 10), (4, 20), (5, 20)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	aView := &view.View{
+		Columns: []*view.Column{
+			{
+				Name:     "ID",
+				DataType: "Int",
+			},
+			{
+				Name:     "PRICE",
+				DataType: "Int",
+			},
+		},
+		Name:  "items",
+		Table: "items",
+		Selector: &view.Config{
+			OrderBy: "PRICE",
+			Limit:   2,
+			Constraints: &view.Constraints{
+				Cursor:    true,
+				CursorKey: "secret",
+			},
+		},
+		Template: &view.Template{
+			Schema:         view.NewSchema(reflect.TypeOf(Params{})),
+			PresenceSchema: view.NewSchema(reflect.TypeOf(PresenceMap{})),
+		},
+		Connector: &view.Connector{
+			Name:   "db",
+			DSN:    dsn,
+			Driver: "sqlite3",
+		},
+	}
+
+	if !assert.Nil(t, aView.Init(context.TODO(), view.EmptyResource())) {
+		return
+	}
+	assert.Equal(t, []string{"ID"}, aView.Selector.Constraints.PrimaryKey)
+
+	var ids []int
+	var cursor []interface{}
+	for page := 0; page < 5; page++ {
+		selector := &view.Selector{Cursor: cursor, Parameters: view.ParamState{Values: Params{}, Has: PresenceMap{}}}
+		selector.Init()
+		matcher, err := NewBuilder().Build(aView, selector, &view.BatchData{}, nil, nil, nil, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		rows, err := db.Query(matcher.SQL, matcher.Args...)
+		if !assert.Nil(t, err, matcher.SQL) {
+			return
+		}
+
+		read := 0
+		for rows.Next() {
+			var id, price int
+			if !assert.Nil(t, rows.Scan(&id, &price)) {
+				break
+			}
+			ids = append(ids, id)
+			cursor = []interface{}{price, id}
+			read++
+		}
+		_ = rows.Close()
+
+		if read == 0 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+}
@@ -0,0 +1,190 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/datly/shared"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+type (
+	//BatchFn represents stream batch handler, batch is a pointer to the slice of View records
+	BatchFn func(batch interface{}) error
+
+	streamer struct {
+		service   *Service
+		session   *Session
+		batchSize int
+		onBatch   BatchFn
+		batch     *streamBatch
+		rows      int
+	}
+
+	streamBatch struct {
+		dest      reflect.Value
+		collector *view.Collector
+		newItem   func() interface{}
+		visitor   view.VisitorFn
+		resolvers map[string]func(ptr unsafe.Pointer) interface{}
+		size      int
+	}
+)
+
+//Stream reads Session.View with a single query and passes records to onBatch in batches of batchSize records.
+//Relations are resolved for each batch before it is passed, so that only one batch is held in memory at a time,
+//relations are read with separate connections while the main query rows are still open. Streamed reads bypass the cache.
+func (s *Service) Stream(ctx context.Context, session *Session, batchSize int, onBatch BatchFn) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid stream batch size: %v", batchSize)
+	}
+
+	session.Selectors.Init()
+	aView := session.View
+	selector := session.Selectors.Lookup(aView)
+	data, _ := session.ParentData()
+	matcher, err := s.sqlBuilder.Build(aView, selector, &view.BatchData{}, nil, nil, data.AsParam(), nil)
+	if err != nil {
+		return err
+	}
+
+	db, err := aView.Db()
+	if err != nil {
+		return err
+	}
+
+	aStreamer := &streamer{service: s, session: session, batchSize: batchSize, onBatch: onBatch}
+	aStreamer.batch = aStreamer.newBatch(ctx)
+	stats := s.NewStats(session, matcher, nil, nil)
+	info := &Info{View: aView.Name}
+	start := Now()
+	defer func() {
+		elapsed := Dif(Now(), start)
+		info.Elapsed = elapsed.String()
+		session.AddMetric(&Metric{View: aView.Name, ElapsedMs: int(elapsed.Milliseconds()), Elapsed: elapsed.String(), Rows: aStreamer.rows})
+		session.AddInfo(info)
+	}()
+
+	reader, err := read.New(ctx, db, aView.Dialect().EnsurePlaceholders(matcher.SQL), aStreamer.newItem, io.Resolve(aStreamer.resolve))
+	if err != nil {
+		_, err = s.HandleSQLError(err, session, aView, matcher, stats)
+		return err
+	}
+
+	defer func() {
+		stmt := reader.Stmt()
+		if stmt == nil {
+			return
+		}
+
+		_ = stmt.Close()
+	}()
+
+	info.Template = append(info.Template, stats)
+	var batchErr error
+	begin := time.Now()
+	err = reader.QueryAll(ctx, func(row interface{}) error {
+		batchErr = aStreamer.visit(ctx, row)
+		return batchErr
+	}, matcher.Args...)
+	aView.Logger.ReadingData(time.Since(begin), matcher.SQL, aStreamer.rows, matcher.Args, err)
+	if batchErr != nil {
+		return batchErr
+	}
+
+	if err != nil {
+		_, err = s.HandleSQLError(err, session, aView, matcher, stats)
+		return err
+	}
+
+	return aStreamer.flush(ctx)
+}
+
+func (s *streamer) newBatch(ctx context.Context) *streamBatch {
+	aView := s.session.View
+	dest := reflect.New(aView.Schema.SliceType())
+	collector := aView.Collector(dest.Interface(), s.session.HandleViewMeta, aView.MatchStrategy.SupportsParallel())
+	return &streamBatch{
+		dest:      dest,
+		collector: collector,
+		newItem:   collector.NewItem(),
+		visitor:   collector.Visitor(ctx),
+		resolvers: map[string]func(ptr unsafe.Pointer) interface{}{},
+	}
+}
+
+func (s *streamer) newItem() interface{} {
+	return s.batch.newItem()
+}
+
+func (s *streamer) resolve(column io.Column) func(ptr unsafe.Pointer) interface{} {
+	return func(ptr unsafe.Pointer) interface{} {
+		resolver, ok := s.batch.resolvers[column.Name()]
+		if !ok {
+			resolver = s.batch.collector.Resolve(column)
+			s.batch.resolvers[column.Name()] = resolver
+		}
+
+		return resolver(ptr)
+	}
+}
+
+func (s *streamer) visit(ctx context.Context, row interface{}) error {
+	row, err := s.session.View.UnwrapDatabaseType(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if fetcher, ok := row.(OnFetcher); ok {
+		if err = fetcher.OnFetch(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err = s.batch.visitor(row); err != nil {
+		return err
+	}
+
+	s.rows++
+	s.batch.size++
+	if s.batch.size < s.batchSize {
+		return nil
+	}
+
+	return s.flush(ctx)
+}
+
+//flush resolves current batch relations and passes the batch to onBatch
+func (s *streamer) flush(ctx context.Context) error {
+	batch := s.batch
+	if batch.size == 0 {
+		return nil
+	}
+
+	s.batch = s.newBatch(ctx)
+	wg := &sync.WaitGroup{}
+	errors := shared.NewErrors(0)
+	relationGroup, err := s.service.readRelations(ctx, s.session, batch.collector, wg, errors)
+	if err != nil {
+		return err
+	}
+
+	batch.collector.Fetched()
+	if !batch.collector.SupportsParallel() {
+		relationGroup.Wait()
+		s.service.onRelation(ctx, batch.collector)
+	}
+
+	wg.Wait()
+	if err = errors.Error(); err != nil {
+		return err
+	}
+
+	batch.collector.MergeData()
+	return s.onBatch(batch.dest.Interface())
+}
@@ -0,0 +1,21 @@
+package aggregate
+
+import (
+	matcher2 "github.com/viant/datly/router/criteria/matcher"
+	"github.com/viant/parsly"
+	"github.com/viant/parsly/matcher"
+)
+
+const (
+	whitespaceToken int = iota
+	parenthesesToken
+	comaToken
+	identityToken
+	asteriskToken
+)
+
+var whitespaceMatcher = parsly.NewToken(whitespaceToken, "Whitespace", matcher.NewWhiteSpace())
+var parenthesesMatcher = parsly.NewToken(parenthesesToken, "Parentheses", matcher.NewBlock('(', ')', '\\'))
+var comaMatcher = parsly.NewToken(comaToken, "Coma", matcher.NewByte(','))
+var identityMatcher = parsly.NewToken(identityToken, "Identity", matcher2.NewIdentity())
+var asteriskMatcher = parsly.NewToken(asteriskToken, "Asterisk", matcher.NewByte('*'))
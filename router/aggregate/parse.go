@@ -0,0 +1,135 @@
+package aggregate
+
+import (
+	"fmt"
+	"github.com/viant/datly/view"
+	"github.com/viant/parsly"
+	"strings"
+)
+
+//ParseGroupBy parses coma separated group by columns, i.e. Region, Country
+func ParseGroupBy(groupBy string, columns view.ColumnIndex, aggregation *view.Aggregation) ([]string, error) {
+	var result []string
+	cursor := parsly.NewCursor("", []byte(strings.TrimSpace(groupBy)), 0)
+	for cursor.HasMore() {
+		if err := matchSeparator(cursor, len(result)); err != nil {
+			return nil, err
+		}
+
+		column, err := matchColumn(cursor, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		if !aggregation.CanGroupBy(column) {
+			return nil, fmt.Errorf("can't group by column %v", column.Name)
+		}
+
+		for _, candidate := range result {
+			if candidate == column.Name {
+				return nil, fmt.Errorf("duplicated group by column %v", column.Name)
+			}
+		}
+
+		result = append(result, column.Name)
+	}
+
+	return result, nil
+}
+
+//ParseAggregates parses coma separated aggregate functions, i.e. count(*), sum(Price)
+func ParseAggregates(aggregates string, columns view.ColumnIndex, aggregation *view.Aggregation) ([]*view.Aggregate, error) {
+	var result []*view.Aggregate
+	cursor := parsly.NewCursor("", []byte(strings.TrimSpace(aggregates)), 0)
+	for cursor.HasMore() {
+		if err := matchSeparator(cursor, len(result)); err != nil {
+			return nil, err
+		}
+
+		aggregate, err := matchAggregate(cursor, columns, aggregation)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range result {
+			if candidate.Name == aggregate.Name {
+				return nil, fmt.Errorf("duplicated aggregate %v", aggregate.Name)
+			}
+		}
+
+		result = append(result, aggregate)
+	}
+
+	return result, nil
+}
+
+func matchSeparator(cursor *parsly.Cursor, matched int) error {
+	if matched == 0 {
+		return nil
+	}
+
+	if candidate := cursor.MatchAfterOptional(whitespaceMatcher, comaMatcher); candidate.Code != comaToken {
+		return cursor.NewError(comaMatcher)
+	}
+
+	return nil
+}
+
+func matchColumn(cursor *parsly.Cursor, columns view.ColumnIndex) (*view.Column, error) {
+	matched := cursor.MatchAfterOptional(whitespaceMatcher, identityMatcher)
+	if matched.Code != identityToken {
+		return nil, cursor.NewError(identityMatcher)
+	}
+
+	column, err := columns.Lookup(matched.Text(cursor))
+	if err != nil {
+		return nil, err
+	}
+
+	cursor.MatchOne(whitespaceMatcher)
+	return column, nil
+}
+
+func matchAggregate(cursor *parsly.Cursor, columns view.ColumnIndex, aggregation *view.Aggregation) (*view.Aggregate, error) {
+	matched := cursor.MatchAfterOptional(whitespaceMatcher, identityMatcher)
+	if matched.Code != identityToken {
+		return nil, cursor.NewError(identityMatcher)
+	}
+
+	fn := strings.ToLower(matched.Text(cursor))
+	if !aggregation.CanUse(fn) {
+		return nil, fmt.Errorf("unsupported aggregate function %v", fn)
+	}
+
+	matched = cursor.MatchAfterOptional(whitespaceMatcher, parenthesesMatcher)
+	if matched.Code != parenthesesToken {
+		return nil, cursor.NewError(parenthesesMatcher)
+	}
+
+	args := matched.Text(cursor)
+	argsCursor := parsly.NewCursor("", []byte(strings.TrimSpace(args[1:len(args)-1])), 0)
+	cursor.MatchOne(whitespaceMatcher)
+
+	if argsCursor.MatchOne(asteriskMatcher).Code == asteriskToken {
+		if fn != view.CountFunc {
+			return nil, fmt.Errorf("%v(*) is not supported", fn)
+		}
+
+		if argsCursor.HasMore() {
+			return nil, argsCursor.NewError(parenthesesMatcher)
+		}
+
+		return view.NewAggregate(fn, nil), nil
+	}
+
+	column, err := matchColumn(argsCursor, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	if argsCursor.HasMore() {
+		return nil, argsCursor.NewError(parenthesesMatcher)
+	}
+
+	return view.NewAggregate(fn, column), nil
+}
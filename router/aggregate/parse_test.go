@@ -0,0 +1,138 @@
+package aggregate_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/aggregate"
+	"github.com/viant/datly/view"
+	"github.com/viant/toolbox/format"
+	"testing"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	columns := view.ColumnIndex{
+		"region":  {Name: "region", DataType: "string"},
+		"country": {Name: "country", DataType: "string"},
+		"price":   {Name: "price", DataType: "float64"},
+	}
+
+	testCases := []struct {
+		description string
+		input       string
+		aggregation *view.Aggregation
+		expect      []string
+		expectErr   bool
+	}{
+		{
+			description: "single column",
+			input:       "region",
+			aggregation: &view.Aggregation{GroupBy: []string{"region"}},
+			expect:      []string{"region"},
+		},
+		{
+			description: "multiple columns",
+			input:       " region , country ",
+			aggregation: &view.Aggregation{GroupBy: []string{"*"}},
+			expect:      []string{"region", "country"},
+		},
+		{
+			description: "column not allowed",
+			input:       "price",
+			aggregation: &view.Aggregation{GroupBy: []string{"region"}},
+			expectErr:   true,
+		},
+		{
+			description: "missing separator",
+			input:       "region country",
+			aggregation: &view.Aggregation{GroupBy: []string{"*"}},
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := aggregate.ParseGroupBy(testCase.input, columns, testCase.aggregation)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestParseAggregates(t *testing.T) {
+	viewColumns := view.Columns{
+		{Name: "price", DataType: "float64"},
+		{Name: "quantity", DataType: "int"},
+	}
+
+	if !assert.Nil(t, viewColumns.Init(view.EmptyResource(), nil, format.CaseLowerUnderscore, true)) {
+		return
+	}
+	columns := viewColumns.Index(format.CaseLowerUnderscore)
+
+	testCases := []struct {
+		description string
+		input       string
+		aggregation *view.Aggregation
+		expect      []*view.Aggregate
+		expectErr   bool
+	}{
+		{
+			description: "count all",
+			input:       "count(*)",
+			aggregation: &view.Aggregation{},
+			expect:      []*view.Aggregate{{Func: "count", Name: "Count"}},
+		},
+		{
+			description: "multiple functions",
+			input:       "SUM(price), max( quantity )",
+			aggregation: &view.Aggregation{},
+			expect: []*view.Aggregate{
+				{Func: "sum", Column: "price", Name: "SumPrice"},
+				{Func: "max", Column: "quantity", Name: "MaxQuantity"},
+			},
+		},
+		{
+			description: "function not allowed",
+			input:       "avg(price)",
+			aggregation: &view.Aggregation{Functions: []string{"sum"}},
+			expectErr:   true,
+		},
+		{
+			description: "asterisk with sum",
+			input:       "sum(*)",
+			aggregation: &view.Aggregation{},
+			expectErr:   true,
+		},
+		{
+			description: "unknown column",
+			input:       "sum(cost)",
+			aggregation: &view.Aggregation{},
+			expectErr:   true,
+		},
+		{
+			description: "duplicated aggregate",
+			input:       "sum(price),sum(price)",
+			aggregation: &view.Aggregation{},
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := aggregate.ParseAggregates(testCase.input, columns, testCase.aggregation)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"context"
+	"github.com/viant/datly/reader"
+	"github.com/viant/datly/router/cache"
+	"github.com/viant/datly/router/marshal/json"
+	"net/http"
+	"reflect"
+	"unsafe"
+)
+
+func (r *Router) aggregateAndWriteResponse(ctx context.Context, session *ReaderSession, entry *cache.Entry) (statusCode int, err error) {
+	aView := session.Route.View
+	aggregateType, err := aView.AggregateType(session.Selectors.Lookup(aView))
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	destValue := reflect.New(reflect.SliceOf(aggregateType))
+	readerSession := reader.NewSession(destValue.Interface(), aView)
+	readerSession.CacheDisabled = session.IsCacheDisabled()
+	readerSession.IncludeSQL = session.IsMetricDebug()
+	readerSession.Selectors = session.Selectors
+	if err = reader.New().Aggregate(ctx, readerSession); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if session.Route.EnableAudit {
+		r.logMetrics(session.Route.URI, readerSession.Metrics, readerSession.Stats)
+	}
+
+	response, responseType := session.Route.wrapAggregate(destValue.Elem())
+	marshaller, err := r.aggregateMarshaller(session, responseType)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	payload, err := marshaller.Marshal(response, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	payloadReader, err := r.compressIfNeeded(payload, session.Route)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	r.addValidators(session, payloadReader, payload, nil)
+
+	if entry != nil {
+		r.updateCache(ctx, session, entry, payloadReader)
+	}
+
+	r.writeResponse(ctx, session, payloadReader)
+	return -1, nil
+}
+
+func (r *Router) aggregateMarshaller(session *ReaderSession, responseType reflect.Type) (FormatMarshaller, error) {
+	switch outputFormat := session.RequestParams.OutputFormat; outputFormat {
+	case JSONFormat:
+		return json.New(responseType, session.Route.jsonConfig())
+	default:
+		if format, ok := formats.LookupContentType(outputFormat); ok {
+			return format.NewMarshaller(responseType, session.Route.jsonConfig())
+		}
+
+		return nil, UnsupportedFormatErr(outputFormat)
+	}
+}
+
+func (r *Route) wrapAggregate(aggregated reflect.Value) (interface{}, reflect.Type) {
+	if r._responseSetter == nil {
+		return aggregated.Interface(), aggregated.Type().Elem()
+	}
+
+	responseType := reflect.StructOf([]reflect.StructField{
+		{
+			Name:      "ResponseStatus",
+			Type:      reflect.TypeOf(ResponseStatus{}),
+			Anonymous: true,
+		},
+		{
+			Name:    r.ResponseField,
+			PkgPath: r.PgkPath(r.ResponseField),
+			Type:    aggregated.Type(),
+		},
+	})
+
+	response := reflect.New(responseType)
+	responsePtr := unsafe.Pointer(response.Pointer())
+	FieldByName(responseType, "ResponseStatus").SetValue(responsePtr, ResponseStatus{Status: "ok"})
+	FieldByName(responseType, r.ResponseField).SetValue(responsePtr, aggregated.Interface())
+	return response.Elem().Interface(), responseType
+}
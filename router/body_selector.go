@@ -8,10 +8,14 @@ import (
 	"strings"
 )
 
+//ExecStateValue selects executor Result as the response body
+const ExecStateValue = "$Exec"
+
 type (
 	BodySelector struct {
-		Query      string
-		StateValue string
+		Query       string
+		StateValue  string
+		ResultField string `json:",omitempty"` //Comprehensive style response field holding executor Result
 
 		_bodyType reflect.Type
 		_accessor *view.Accessor
@@ -23,6 +27,11 @@ func (s *BodySelector) Init(aView *view.View) error {
 		return fmt.Errorf("param name was not specified")
 	}
 
+	if s.StateValue == ExecStateValue {
+		s._bodyType = reflect.TypeOf(&executor.Result{})
+		return nil
+	}
+
 	stateType := aView.Template.StateType()
 
 	accessors := view.NewAccessors()
@@ -54,5 +63,9 @@ func (s *BodySelector) stateValue(aView *view.View) string {
 }
 
 func (s *BodySelector) getValue(session *executor.Session) (interface{}, error) {
+	if s.StateValue == ExecStateValue {
+		return session.Result(), nil
+	}
+
 	return s._accessor.Value(session.State.Mem)
 }
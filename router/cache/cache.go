@@ -8,21 +8,27 @@ import (
 	"github.com/google/uuid"
 	"github.com/viant/afs"
 	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
 	"hash/fnv"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type (
 	Cache struct {
-		TimeToLiveMs int
-		Location     string
-
-		_ttl time.Duration
-		afs  afs.Service
+		TimeToLiveMs           int
+		StaleWhileRevalidateMs int `json:",omitempty"` //time after expiry when stale entry is served while it is refreshed in the background
+		Location               string
+
+		_ttl    time.Duration
+		_stale  time.Duration
+		afs     afs.Service
+		flights map[uint64]*flight
+		mux     sync.Mutex
 	}
 
 	LineReadCloser struct {
@@ -51,7 +57,9 @@ func (c *LineReadCloser) ReadLine() ([]byte, error) {
 
 func (c *Cache) Init(ctx context.Context) error {
 	c._ttl = time.Duration(c.TimeToLiveMs) * time.Millisecond
+	c._stale = time.Duration(c.StaleWhileRevalidateMs) * time.Millisecond
 	c.afs = afs.New()
+	c.flights = map[uint64]*flight{}
 
 	return nil
 }
@@ -62,7 +70,16 @@ func (c *Cache) Get(ctx context.Context, selectors []byte, viewName string) (*En
 		return nil, err
 	}
 
-	entry := &Entry{
+	entry := c.newEntry(selectors, viewName, key)
+	if err = c.read(ctx, entry); err != nil || entry.Has() {
+		return entry, err
+	}
+
+	return entry, c.coalesce(ctx, entry)
+}
+
+func (c *Cache) newEntry(selectors []byte, viewName string, key uint64) *Entry {
+	return &Entry{
 		cache: c,
 		meta: Meta{
 			View:      viewName,
@@ -72,20 +89,19 @@ func (c *Cache) Get(ctx context.Context, selectors []byte, viewName string) (*En
 		id:  strings.ReplaceAll(uuid.New().String(), "-", ""),
 		key: key,
 	}
-
-	return entry, c.read(ctx, entry)
 }
 
 func (c *Cache) close(ctx context.Context, entry *Entry) error {
-	if entry.reader == nil {
+	actualURL := strings.ReplaceAll(entry.meta.url, ".json"+entry.id, ".json")
+	if actualURL == entry.meta.url {
 		return nil
 	}
 
-	actualURL := strings.ReplaceAll(entry.meta.url, ".json"+entry.id, ".json")
 	return c.afs.Move(ctx, entry.meta.url, actualURL)
 }
 
 func (c *Cache) Put(ctx context.Context, entry *Entry, response []byte, compressionType string, headers http.Header) error {
+	defer c.Release(entry)
 	if entry.reader != nil {
 		return entry.Close()
 	}
@@ -105,15 +121,48 @@ func (c *Cache) Put(ctx context.Context, entry *Entry, response []byte, compress
 		return err
 	}
 
-	defer writeCloser.Close()
 	if err = c.write(writeCloser, metaBytes, []byte("\n"), response); err != nil {
+		_ = writeCloser.Close()
 		_ = c.afs.Delete(ctx, entry.meta.url)
 		return err
 	}
 
+	if err = writeCloser.Close(); err != nil {
+		return err
+	}
+
 	return c.close(ctx, entry)
 }
 
+//Purge removes all cached responses stored under the Location
+func (c *Cache) Purge(ctx context.Context) error {
+	parent, prefix := url.Split(c.Location, file.Scheme)
+	if strings.HasSuffix(c.Location, "/") {
+		parent, prefix = c.Location, ""
+	}
+
+	if ok, _ := c.afs.Exists(ctx, parent); !ok {
+		return nil
+	}
+
+	objects, err := c.afs.List(ctx, parent)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if object.IsDir() || !strings.HasPrefix(object.Name(), prefix) || !strings.Contains(object.Name(), ".json") {
+			continue
+		}
+
+		if err = c.afs.Delete(ctx, object.URL()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Cache) Combine(location string, selectors []byte, name string) (uint64, error) {
 	keySize := len(location) + len(selectors) + len(name) + 2
 	key := make([]byte, keySize)
@@ -170,11 +219,21 @@ func (c *Cache) validateMeta(ctx context.Context, entry *Entry, line []byte) (bo
 		return false, err
 	}
 
-	now := Now()
-	if now.After(cachedMeta.ExpireAt) || !bytes.Equal(entry.meta.Selectors, cachedMeta.Selectors) || entry.meta.View != cachedMeta.View {
+	if !bytes.Equal(entry.meta.Selectors, cachedMeta.Selectors) || entry.meta.View != cachedMeta.View {
 		return false, c.afs.Delete(ctx, entry.meta.url)
 	}
 
+	now := Now()
+	if now.After(cachedMeta.ExpireAt) {
+		if entry.stale = now.Before(cachedMeta.ExpireAt.Add(c._stale)); !entry.stale {
+			return false, c.afs.Delete(ctx, entry.meta.url)
+		}
+	}
+
+	entry.meta.ExpireAt = cachedMeta.ExpireAt
+	entry.meta.Size = cachedMeta.Size
+	entry.meta.CompressionType = cachedMeta.CompressionType
+	entry.meta.ExtraHeaders = cachedMeta.ExtraHeaders
 	return true, nil
 }
 
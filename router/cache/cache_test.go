@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCache_Get(t *testing.T) {
+	defer func() { Now = time.Now }()
+	started := time.Now()
+	testcases := []struct {
+		description string
+		elapsed     time.Duration
+		expectHas   bool
+		expectStale bool
+	}{
+		{description: "fresh entry", elapsed: 500 * time.Millisecond, expectHas: true},
+		{description: "stale entry", elapsed: 1500 * time.Millisecond, expectHas: true, expectStale: true},
+		{description: "expired entry", elapsed: 2500 * time.Millisecond},
+	}
+
+	for i, testcase := range testcases {
+		ctx := context.TODO()
+		aCache := &Cache{TimeToLiveMs: 1000, StaleWhileRevalidateMs: 1000, Location: "mem://localhost/router/cache/get/" + strconv.Itoa(i) + "/"}
+		assert.Nil(t, aCache.Init(ctx), testcase.description)
+		assert.Nil(t, aCache.Purge(ctx), testcase.description)
+
+		Now = func() time.Time { return started }
+		entry, err := aCache.Get(ctx, []byte("{}"), "events")
+		assert.Nil(t, err, testcase.description)
+		assert.False(t, entry.Has(), testcase.description)
+		assert.Nil(t, aCache.Put(ctx, entry, []byte("[1]"), "", nil), testcase.description)
+
+		Now = func() time.Time { return started.Add(testcase.elapsed) }
+		entry, err = aCache.Get(ctx, []byte("{}"), "events")
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, testcase.expectHas, entry.Has(), testcase.description)
+		assert.Equal(t, testcase.expectStale, entry.Stale(), testcase.description)
+		if !entry.Has() {
+			aCache.Release(entry)
+			continue
+		}
+		_ = entry.Close()
+
+		if !entry.Stale() {
+			continue
+		}
+
+		refreshed := make(chan bool, 2)
+		refresh := func(fresh *Entry) {
+			assert.Nil(t, aCache.Put(ctx, fresh, []byte("[2]"), "", nil), testcase.description)
+			refreshed <- true
+		}
+		aCache.Revalidate(entry, refresh)
+		aCache.Revalidate(entry, refresh)
+		<-refreshed
+		assert.Equal(t, 0, len(refreshed), testcase.description)
+
+		entry, err = aCache.Get(ctx, []byte("{}"), "events")
+		assert.Nil(t, err, testcase.description)
+		assert.False(t, entry.Stale(), testcase.description)
+		assert.Equal(t, "[2]", readEntry(t, entry), testcase.description)
+	}
+}
+
+func TestCache_Coalesce(t *testing.T) {
+	testcases := []struct {
+		description string
+		store       bool
+		expectHas   bool
+	}{
+		{description: "waiting request reads stored entry", store: true, expectHas: true},
+		{description: "waiting request reads database once entry is released", store: false},
+	}
+
+	for i, testcase := range testcases {
+		ctx := context.TODO()
+		aCache := &Cache{TimeToLiveMs: 60000, Location: "mem://localhost/router/cache/coalesce/" + strconv.Itoa(i) + "/"}
+		assert.Nil(t, aCache.Init(ctx), testcase.description)
+		assert.Nil(t, aCache.Purge(ctx), testcase.description)
+
+		leader, err := aCache.Get(ctx, []byte("{}"), "events")
+		assert.Nil(t, err, testcase.description)
+
+		waiting := make(chan *Entry)
+		go func() {
+			entry, err := aCache.Get(ctx, []byte("{}"), "events")
+			assert.Nil(t, err, testcase.description)
+			waiting <- entry
+		}()
+
+		select {
+		case <-waiting:
+			assert.Fail(t, "request should wait for the entry being read", testcase.description)
+			continue
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		if testcase.store {
+			assert.Nil(t, aCache.Put(ctx, leader, []byte("[1]"), "", nil), testcase.description)
+		} else {
+			aCache.Release(leader)
+		}
+
+		entry := <-waiting
+		assert.Equal(t, testcase.expectHas, entry.Has(), testcase.description)
+		if entry.Has() {
+			assert.Equal(t, 3, entry.Size(), testcase.description)
+			assert.Equal(t, "[1]", readEntry(t, entry), testcase.description)
+		}
+	}
+}
+
+func readEntry(t *testing.T, entry *Entry) string {
+	data, err := ioutil.ReadAll(entry)
+	assert.Nil(t, err)
+	_ = entry.Close()
+	return string(data)
+}
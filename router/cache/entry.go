@@ -12,6 +12,8 @@ type (
 		id     string
 		key    uint64
 		reader *LineReadCloser
+		stale  bool
+		flight *flight
 
 		closed bool
 		cache  *Cache
@@ -65,3 +67,8 @@ func (e *Entry) Reader() (io.Reader, bool) {
 func (e *Entry) Has() bool {
 	return e.reader != nil
 }
+
+//Stale returns true if entry has expired, but is still within the stale-while-revalidate window
+func (e *Entry) Stale() bool {
+	return e.stale
+}
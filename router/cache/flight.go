@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//maxFlightWait limits how long concurrent misses wait for the entry being read, afterwards they read the entry themselves
+var maxFlightWait = 30 * time.Second
+
+//flight represents entry read in progress, shared by concurrent requests with the same entry key
+type flight struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func (f *flight) release() {
+	f.once.Do(func() {
+		close(f.done)
+	})
+}
+
+//coalesce makes entry the key flight leader, or waits until the leader stores the entry and reads it again
+func (c *Cache) coalesce(ctx context.Context, entry *Entry) error {
+	aFlight, leader := c.beginFlight(entry.key)
+	if leader {
+		entry.flight = aFlight
+		return nil
+	}
+
+	select {
+	case <-aFlight.done:
+	case <-time.After(maxFlightWait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return c.read(ctx, entry)
+}
+
+//Revalidate refreshes stale entry in the background, only one refresh runs for the entry key at a time
+//refresh has to store fresh entry with Put, or Release it
+func (c *Cache) Revalidate(entry *Entry, refresh func(fresh *Entry)) {
+	aFlight, leader := c.beginFlight(entry.key)
+	if !leader {
+		return
+	}
+
+	fresh := c.newEntry(entry.meta.Selectors, entry.meta.View, entry.key)
+	fresh.meta.url += fresh.id //written aside and moved once complete, stale entry can be still being read
+	fresh.flight = aFlight
+	go refresh(fresh)
+}
+
+//Release releases entry flight, waiting requests read the entry again
+func (c *Cache) Release(entry *Entry) {
+	if entry == nil || entry.flight == nil {
+		return
+	}
+
+	c.mux.Lock()
+	if c.flights[entry.key] == entry.flight {
+		delete(c.flights, entry.key)
+	}
+	c.mux.Unlock()
+
+	entry.flight.release()
+}
+
+func (c *Cache) beginFlight(key uint64) (*flight, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if aFlight, ok := c.flights[key]; ok {
+		return aFlight, false
+	}
+
+	aFlight := &flight{done: make(chan struct{})}
+	c.flights[key] = aFlight
+	return aFlight, true
+}
@@ -0,0 +1,105 @@
+package router_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router"
+	"github.com/viant/datly/view"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type readCounter struct {
+	reads int32
+}
+
+func (c *readCounter) Value(ctx context.Context, raw interface{}, options ...interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (c *readCounter) AfterFetch(data interface{}, response http.ResponseWriter, request *http.Request) (responseClosed bool, err error) {
+	atomic.AddInt32(&c.reads, 1)
+	return false, nil
+}
+
+func (c *readCounter) count() int32 {
+	return atomic.LoadInt32(&c.reads)
+}
+
+func TestRouter_CacheFlight(t *testing.T) {
+	expected := `[{"Id":1,"Timestamp":"2019-03-11T02:20:33Z","EventTypeId":2,"Quantity":33.23432374000549,"UserId":1},{"Id":10,"Timestamp":"2019-03-15T12:07:33Z","EventTypeId":11,"Quantity":21.957962334156036,"UserId":2},{"Id":100,"Timestamp":"2019-04-10T05:15:33Z","EventTypeId":111,"Quantity":5.084940046072006,"UserId":3}]`
+	flightCases := []struct {
+		description string
+		uri         string
+		warmup      bool
+		requests    int
+		expectReads int32
+	}{
+		{
+			description: "concurrent misses read once",
+			uri:         "/api/events",
+			requests:    10,
+			expectReads: 1,
+		},
+		{
+			description: "concurrent stale hits revalidate once",
+			uri:         "/api/stale-events",
+			warmup:      true,
+			requests:    10,
+			expectReads: 2,
+		},
+	}
+
+	for _, flightCase := range flightCases {
+		counter := &readCounter{}
+		aCase := &testcase{
+			description: flightCase.description,
+			resourceURI: "046_cache_flight",
+			visitors:    view.NewCodecs(view.NewVisitor("event_counter", counter)),
+		}
+
+		aRouter, ok := aCase.init(t, "./testdata")
+		if !ok {
+			continue
+		}
+
+		for _, route := range aRouter.Routes("") {
+			assert.Nil(t, route.Cache.Purge(context.TODO()), flightCase.description)
+		}
+
+		if flightCase.warmup {
+			assert.Equal(t, expected, sendRequest(t, aRouter, flightCase.uri), flightCase.description)
+			time.Sleep(400 * time.Millisecond)
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(flightCase.requests)
+		for i := 0; i < flightCase.requests; i++ {
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, expected, sendRequest(t, aRouter, flightCase.uri), flightCase.description)
+			}()
+		}
+		wg.Wait()
+
+		for deadline := time.Now().Add(5 * time.Second); counter.count() < flightCase.expectReads && time.Now().Before(deadline); {
+			time.Sleep(400 * time.Millisecond)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, flightCase.expectReads, counter.count(), flightCase.description)
+	}
+}
+
+func sendRequest(t *testing.T, aRouter *router.Router, uri string) string {
+	responseWriter := httptest.NewRecorder()
+	assert.Nil(t, aRouter.Handle(responseWriter, httptest.NewRequest(http.MethodGet, uri, nil)))
+	response, err := ioutil.ReadAll(responseWriter.Result().Body)
+	assert.Nil(t, err)
+	return string(response)
+}
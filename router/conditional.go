@@ -0,0 +1,100 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+const (
+	HeaderETag            = "ETag"
+	HeaderLastModified    = "Last-Modified"
+	HeaderIfNoneMatch     = "If-None-Match"
+	HeaderIfModifiedSince = "If-Modified-Since"
+	HeaderIfMatch         = "If-Match"
+)
+
+//ETag returns strong entity tag computed from the marshalled payload
+func ETag(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func (r *Router) addValidators(session *ReaderSession, payloadReader *RequestDataReader, marshalled []byte, slicePtr unsafe.Pointer) {
+	payloadReader.AddHeader(HeaderETag, ETag(marshalled))
+	if slicePtr == nil {
+		return
+	}
+
+	if lastModified, ok := session.Route.View.LastModified(slicePtr); ok {
+		payloadReader.AddHeader(HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+func (r *Router) writeNotModifiedIfNeeded(session *ReaderSession, payloadReader PayloadReader) bool {
+	headers := payloadReader.Headers()
+	if !isNotModified(session.Request, headers) {
+		return false
+	}
+
+	responseHeaders := session.Response.Header()
+	for _, name := range []string{HeaderETag, HeaderLastModified} {
+		if value := headers.Get(name); value != "" {
+			responseHeaders.Set(name, value)
+		}
+	}
+
+	responseHeaders.Add(HeaderVary, HeaderAccept)
+	session.Response.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func isNotModified(request *http.Request, headers http.Header) bool {
+	if request == nil || headers == nil {
+		return false
+	}
+
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := request.Header.Get(HeaderIfNoneMatch); ifNoneMatch != "" {
+		return matchesETag(ifNoneMatch, headers.Get(HeaderETag))
+	}
+
+	ifModifiedSince := request.Header.Get(HeaderIfModifiedSince)
+	lastModified := headers.Get(HeaderLastModified)
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.Truncate(time.Second).After(since)
+}
+
+func matchesETag(ifNoneMatch string, eTag string) bool {
+	if eTag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(eTag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
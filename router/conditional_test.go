@@ -0,0 +1,87 @@
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestIsNotModified(t *testing.T) {
+	eTag := ETag([]byte(`[{"Id":1}]`))
+	testcases := []struct {
+		description string
+		method      string
+		request     map[string]string
+		response    map[string]string
+		expect      bool
+	}{
+		{
+			description: "no conditional headers",
+			response:    map[string]string{HeaderETag: eTag},
+		},
+		{
+			description: "matching etag",
+			request:     map[string]string{HeaderIfNoneMatch: eTag},
+			response:    map[string]string{HeaderETag: eTag},
+			expect:      true,
+		},
+		{
+			description: "matching weak etag in list",
+			request:     map[string]string{HeaderIfNoneMatch: `"abc", W/` + eTag},
+			response:    map[string]string{HeaderETag: eTag},
+			expect:      true,
+		},
+		{
+			description: "wildcard etag",
+			request:     map[string]string{HeaderIfNoneMatch: "*"},
+			response:    map[string]string{HeaderETag: eTag},
+			expect:      true,
+		},
+		{
+			description: "changed etag",
+			request:     map[string]string{HeaderIfNoneMatch: `"abc"`},
+			response:    map[string]string{HeaderETag: eTag},
+		},
+		{
+			description: "etag takes precedence over modified since",
+			request:     map[string]string{HeaderIfNoneMatch: `"abc"`, HeaderIfModifiedSince: "Wed, 21 Oct 2015 07:28:00 GMT"},
+			response:    map[string]string{HeaderETag: eTag, HeaderLastModified: "Wed, 21 Oct 2015 07:28:00 GMT"},
+		},
+		{
+			description: "not modified since",
+			request:     map[string]string{HeaderIfModifiedSince: "Wed, 21 Oct 2015 07:28:00 GMT"},
+			response:    map[string]string{HeaderETag: eTag, HeaderLastModified: "Wed, 21 Oct 2015 07:28:00 GMT"},
+			expect:      true,
+		},
+		{
+			description: "modified since",
+			request:     map[string]string{HeaderIfModifiedSince: "Wed, 21 Oct 2015 07:28:00 GMT"},
+			response:    map[string]string{HeaderETag: eTag, HeaderLastModified: "Wed, 21 Oct 2015 07:28:01 GMT"},
+		},
+		{
+			description: "non GET method",
+			method:      http.MethodPost,
+			request:     map[string]string{HeaderIfNoneMatch: eTag},
+			response:    map[string]string{HeaderETag: eTag},
+		},
+	}
+
+	for _, testcase := range testcases {
+		method := testcase.method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		request := &http.Request{Method: method, Header: asHeader(testcase.request)}
+		assert.Equal(t, testcase.expect, isNotModified(request, asHeader(testcase.response)), testcase.description)
+	}
+}
+
+func asHeader(values map[string]string) http.Header {
+	result := http.Header{}
+	for key, value := range values {
+		result.Set(key, value)
+	}
+
+	return result
+}
@@ -1,6 +1,24 @@
 package criteria
 
-type Criteria struct {
-	Expression   string
-	Placeholders []interface{}
+type (
+	Criteria struct {
+		Expression   string
+		Placeholders []interface{}
+	}
+
+	//FilterError represents column filter rule violation
+	FilterError struct {
+		Column   string
+		Operator string `json:",omitempty"`
+		Message  string
+	}
+)
+
+//NewFilterError creates FilterError
+func NewFilterError(column, operator, message string) *FilterError {
+	return &FilterError{Column: column, Operator: operator, Message: message}
+}
+
+func (e *FilterError) Error() string {
+	return e.Message
 }
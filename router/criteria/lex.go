@@ -4,6 +4,7 @@ import (
 	matcher2 "github.com/viant/datly/router/criteria/matcher"
 	"github.com/viant/parsly"
 	"github.com/viant/parsly/matcher"
+	"github.com/viant/parsly/matcher/option"
 )
 
 type Token int
@@ -32,7 +33,16 @@ const (
 	lowerToken
 	lowerEqualToken
 	likeToken
+	notLikeToken
+	ilikeToken
+	notIlikeToken
 	inToken
+	notInToken
+	betweenToken
+	isNullToken
+	isNotNullToken
+
+	notToken
 )
 
 var whitespaceMatcher = parsly.NewToken(whitespaceToken, "Whitespace", matcher.NewWhiteSpace())
@@ -58,4 +68,13 @@ var greaterEqualMatcher = parsly.NewToken(greaterEqualToken, "Greater or equal",
 var lowerMatcher = parsly.NewToken(lowerToken, "Lower", matcher.NewByte('<'))
 var lowerEqualMatcher = parsly.NewToken(lowerEqualToken, "Lower or equal", matcher.NewFragment("<="))
 var likeMatcher = parsly.NewToken(likeToken, "Like", matcher.NewFragmentsFold([]byte("like")))
+var notLikeMatcher = parsly.NewToken(notLikeToken, "Not like", matcher.NewSpacedFragment("not like", &option.Case{}))
+var ilikeMatcher = parsly.NewToken(ilikeToken, "Ilike", matcher.NewFragmentsFold([]byte("ilike")))
+var notIlikeMatcher = parsly.NewToken(notIlikeToken, "Not ilike", matcher.NewSpacedFragment("not ilike", &option.Case{}))
 var inMatcher = parsly.NewToken(inToken, "In", matcher.NewFragmentsFold([]byte("in")))
+var notInMatcher = parsly.NewToken(notInToken, "Not in", matcher.NewSpacedFragment("not in", &option.Case{}))
+var betweenMatcher = parsly.NewToken(betweenToken, "Between", matcher.NewFragmentsFold([]byte("between")))
+var isNullMatcher = parsly.NewToken(isNullToken, "Is null", matcher.NewSpacedFragment("is null", &option.Case{}))
+var isNotNullMatcher = parsly.NewToken(isNotNullToken, "Is not null", matcher.NewSpacedFragment("is not null", &option.Case{}))
+
+var notMatcher = parsly.NewToken(notToken, "Not", matcher2.NewKeyword("not"))
@@ -0,0 +1,30 @@
+package matcher
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+)
+
+type keyword struct {
+	value []byte
+}
+
+//Match matches case insensitive keyword, not followed by the identifier character
+func (k *keyword) Match(cursor *parsly.Cursor) (matched int) {
+	input := cursor.Input
+	end := cursor.Pos + len(k.value)
+	if end > len(input) || !bytes.EqualFold(input[cursor.Pos:end], k.value) {
+		return 0
+	}
+
+	if end < len(input) && (IsLetter(input[end]) || input[end] == '_' || (input[end] >= '0' && input[end] <= '9')) {
+		return 0
+	}
+
+	return len(k.value)
+}
+
+//NewKeyword creates a keyword matcher
+func NewKeyword(value string) *keyword {
+	return &keyword{value: []byte(value)}
+}
@@ -10,11 +10,40 @@ import (
 	"strings"
 )
 
-var numericTokens = []*parsly.Token{notEqualMatcher, equalMatcher, greaterEqualMatcher, greaterMatcher, lowerEqualMatcher, lowerMatcher, inMatcher}
+const likeEscape = '\\'
+
+var numericTokens = []*parsly.Token{notEqualMatcher, equalMatcher, greaterEqualMatcher, greaterMatcher, lowerEqualMatcher, lowerMatcher, notInMatcher, inMatcher, betweenMatcher, isNotNullMatcher, isNullMatcher}
+var boolTokens = []*parsly.Token{notEqualMatcher, equalMatcher, notInMatcher, inMatcher, isNotNullMatcher, isNullMatcher}
+var stringTokens = []*parsly.Token{notEqualMatcher, equalMatcher, notLikeMatcher, notIlikeMatcher, likeMatcher, ilikeMatcher, notInMatcher, inMatcher, betweenMatcher, isNotNullMatcher, isNullMatcher}
+
+var tokenOperators = map[int]string{
+	equalToken:        view.EqualOperator,
+	notEqualToken:     view.NotEqualOperator,
+	greaterToken:      view.GreaterOperator,
+	greaterEqualToken: view.GreaterEqualOperator,
+	lowerToken:        view.LowerOperator,
+	lowerEqualToken:   view.LowerEqualOperator,
+	likeToken:         view.LikeOperator,
+	notLikeToken:      view.NotLikeOperator,
+	ilikeToken:        view.IlikeOperator,
+	notIlikeToken:     view.NotIlikeOperator,
+	inToken:           view.InOperator,
+	notInToken:        view.NotInOperator,
+	betweenToken:      view.BetweenOperator,
+	isNullToken:       view.IsNullOperator,
+	isNotNullToken:    view.IsNotNullOperator,
+}
+
+type parser struct {
+	placeholders []interface{}
+	columns      view.ColumnIndex
+	methods      map[string]*view.Method
+	used         []*view.Column
+	conjuncts    []*view.Column
+}
 
 func Parse(criteria string, columns view.ColumnIndex, methods map[string]*view.Method) (*Criteria, error) {
 	buffer := bytes.Buffer{}
-	placeholders := make([]interface{}, 0)
 
 	criteria = strings.TrimSpace(criteria)
 	if len(criteria) == 0 {
@@ -24,69 +53,130 @@ func Parse(criteria string, columns view.ColumnIndex, methods map[string]*view.M
 		}, nil
 	}
 
+	aParser := &parser{
+		placeholders: make([]interface{}, 0),
+		columns:      columns,
+		methods:      methods,
+	}
+
 	cursor := parsly.NewCursor("", []byte(criteria), 0)
-	if err := parse(cursor, &buffer, &placeholders, columns, methods); err != nil {
+	conjuncts, err := aParser.parse(cursor, &buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	aParser.conjuncts = conjuncts
+
+	if err := aParser.validateRequired(); err != nil {
 		return nil, err
 	}
 
 	return &Criteria{
 		Expression:   buffer.String(),
-		Placeholders: placeholders,
+		Placeholders: aParser.placeholders,
 	}, nil
 }
 
-func parse(cursor *parsly.Cursor, buffer *bytes.Buffer, placeholders *[]interface{}, columns view.ColumnIndex, methods map[string]*view.Method) error {
+//parse parses criteria and returns left-hand columns of predicates in AND conjunct position,
+//which every matched row has to satisfy
+func (p *parser) parse(cursor *parsly.Cursor, buffer *bytes.Buffer) ([]*view.Column, error) {
+	var conjuncts []*view.Column
+	disjunction := false
 	isFirstTime := true
 	for cursor.Pos < cursor.InputSize {
 		if !isFirstTime {
-			if err := matchOperator(cursor, buffer); err != nil {
-				return err
+			operator, err := matchOperator(cursor, buffer)
+			if err != nil {
+				return nil, err
 			}
+			disjunction = disjunction || operator == orToken
 		}
 		isFirstTime = false
 
-		matched := cursor.MatchAfterOptional(whitespaceMatcher, parenthesesMatcher)
+		negated := false
+		matched := cursor.MatchAfterOptional(whitespaceMatcher, notMatcher, parenthesesMatcher)
+		if matched.Code == notToken {
+			negated = true
+			buffer.WriteString(" NOT")
+			if matched = cursor.MatchAfterOptional(whitespaceMatcher, parenthesesMatcher); matched.Code != parenthesesToken {
+				return nil, cursor.NewError(parenthesesMatcher)
+			}
+		}
+
 		if matched.Code == parenthesesToken {
 			aBlock := matched.Text(cursor)
 			buffer.WriteString(" (")
 			aBlockCursor := parsly.NewCursor("", []byte(aBlock[1:len(aBlock)-1]), 0)
-			if err := parse(aBlockCursor, buffer, placeholders, columns, methods); err != nil {
-				return err
+			blockConjuncts, err := p.parse(aBlockCursor, buffer)
+			if err != nil {
+				return nil, err
 			}
 			buffer.WriteByte(')')
+			if !negated {
+				conjuncts = append(conjuncts, blockConjuncts...)
+			}
 			continue
 		}
 
-		if err := matchExpression(cursor, columns, buffer, placeholders, methods); err != nil {
-			return err
+		column, err := p.matchColumn(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		conjuncts = append(conjuncts, column)
+		if err = p.matchExpression(cursor, column, buffer); err != nil {
+			return nil, err
+		}
+	}
+
+	if disjunction {
+		return nil, nil
+	}
+
+	return conjuncts, nil
+}
+
+func (p *parser) validateRequired() error {
+	for _, column := range p.used {
+		rule := column.FilterRule()
+		if rule == nil {
+			continue
+		}
+
+		for _, required := range rule.Requires {
+			if !p.isConjunct(required) {
+				return NewFilterError(column.Name, "", fmt.Sprintf("column %v can be used only together with column %v", column.Name, required))
+			}
 		}
 	}
 
 	return nil
 }
 
-func matchOperator(cursor *parsly.Cursor, buffer *bytes.Buffer) error {
+func (p *parser) isConjunct(columnName string) bool {
+	for _, column := range p.conjuncts {
+		if column.Name == columnName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchOperator(cursor *parsly.Cursor, buffer *bytes.Buffer) (int, error) {
 	matched := cursor.MatchAfterOptional(whitespaceMatcher, andMatcher, orMatcher)
 	switch matched.Code {
 	case orToken, andToken:
 		buffer.WriteByte(' ')
 		operator := matched.Text(cursor)
 		buffer.WriteString(operator)
-		return nil
+		return matched.Code, nil
 	default:
-		return cursor.NewError(andMatcher, orMatcher)
+		return 0, cursor.NewError(andMatcher, orMatcher)
 	}
 }
 
-func matchExpression(cursor *parsly.Cursor, columns view.ColumnIndex, buffer *bytes.Buffer, placeholders *[]interface{}, methods map[string]*view.Method) error {
-	column, err := matchColumn(cursor, columns)
-	if err != nil {
-		return err
-	}
-
-	buffer.WriteByte(' ')
-	buffer.WriteString(column.Name)
-
+func (p *parser) matchExpression(cursor *parsly.Cursor, column *view.Column, buffer *bytes.Buffer) error {
 	columnType := column.ColumnType()
 	for columnType.Kind() == reflect.Ptr {
 		columnType = columnType.Elem()
@@ -97,18 +187,117 @@ func matchExpression(cursor *parsly.Cursor, columns view.ColumnIndex, buffer *by
 		return err
 	}
 
+	if rule := column.FilterRule(); rule != nil && !rule.CanUse(tokenOperators[matchedToken]) {
+		return NewFilterError(column.Name, tokenOperators[matchedToken], fmt.Sprintf("operator %v is not allowed for column %v", tokenOperators[matchedToken], column.Name))
+	}
+
+	switch matchedToken {
+	case ilikeToken, notIlikeToken:
+		buffer.WriteString(" LOWER(")
+		buffer.WriteString(column.Name)
+		buffer.WriteString(") ")
+		buffer.WriteString(tokenValue)
+		return p.matchLikeValue(cursor, column, buffer, true)
+	}
+
+	buffer.WriteByte(' ')
+	buffer.WriteString(column.Name)
 	buffer.WriteByte(' ')
 	buffer.WriteString(tokenValue)
 
 	switch matchedToken {
-	case inToken:
-		return matchDataSet(cursor, columns, column, buffer, placeholders, methods)
+	case isNullToken, isNotNullToken:
+		return nil
+	case inToken, notInToken:
+		return p.matchDataSet(cursor, column, buffer)
+	case likeToken, notLikeToken:
+		return p.matchLikeValue(cursor, column, buffer, false)
+	case betweenToken:
+		return p.matchRange(cursor, columnType, column.Format, buffer)
 	default:
-		return matchFieldValue(cursor, columns, columnType, column.Format, buffer, placeholders, methods)
+		return p.matchFieldValue(cursor, columnType, column.Format, buffer)
+	}
+}
+
+func (p *parser) matchRange(cursor *parsly.Cursor, columnType reflect.Type, format string, buffer *bytes.Buffer) error {
+	if err := p.matchFieldValue(cursor, columnType, format, buffer); err != nil {
+		return err
+	}
+
+	if matched := cursor.MatchAfterOptional(whitespaceMatcher, andMatcher); matched.Code != andToken {
+		return cursor.NewError(andMatcher)
+	}
+
+	buffer.WriteString(" AND")
+	return p.matchFieldValue(cursor, columnType, format, buffer)
+}
+
+//matchLikeValue matches like pattern, where '\' escapes '%', '_' and '\' wildcard characters,
+//if lower is set, pattern is wrapped with LOWER while ESCAPE clause follows it
+func (p *parser) matchLikeValue(cursor *parsly.Cursor, column *view.Column, buffer *bytes.Buffer, lower bool) error {
+	matched := cursor.MatchAfterOptional(whitespaceMatcher, stringMatcher)
+	if matched.Code != stringToken {
+		if !lower {
+			return p.matchFieldValue(cursor, column.ColumnType(), column.Format, buffer)
+		}
+
+		valueBuffer := bytes.Buffer{}
+		if err := p.matchFieldValue(cursor, column.ColumnType(), column.Format, &valueBuffer); err != nil {
+			return err
+		}
+
+		buffer.WriteString(" LOWER(")
+		buffer.Write(bytes.TrimLeft(valueBuffer.Bytes(), " "))
+		buffer.WriteByte(')')
+		return nil
 	}
+
+	text := matched.Text(cursor)
+	pattern := text[1 : len(text)-1]
+	escaped, err := hasLikeEscape(pattern)
+	if err != nil {
+		return err
+	}
+
+	if lower {
+		buffer.WriteString(" LOWER(?)")
+	} else {
+		buffer.WriteString(" ?")
+	}
+
+	p.placeholders = append(p.placeholders, pattern)
+	if escaped {
+		buffer.WriteString(" ESCAPE ?")
+		p.placeholders = append(p.placeholders, string(likeEscape))
+	}
+
+	return nil
 }
 
-func matchDataSet(cursor *parsly.Cursor, columns view.ColumnIndex, column *view.Column, buffer *bytes.Buffer, placeholders *[]interface{}, methods map[string]*view.Method) error {
+func hasLikeEscape(pattern string) (bool, error) {
+	escaped := false
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != likeEscape {
+			continue
+		}
+
+		if i == len(pattern)-1 {
+			return false, fmt.Errorf("invalid like pattern %v, missing escaped character", pattern)
+		}
+
+		switch pattern[i+1] {
+		case '%', '_', likeEscape:
+			escaped = true
+			i++
+		default:
+			return false, fmt.Errorf("invalid like pattern %v, unsupported escape sequence %v", pattern, pattern[i:i+2])
+		}
+	}
+
+	return escaped, nil
+}
+
+func (p *parser) matchDataSet(cursor *parsly.Cursor, column *view.Column, buffer *bytes.Buffer) error {
 	matched := cursor.MatchAfterOptional(whitespaceMatcher, parenthesesMatcher)
 	switch matched.Code {
 	case parenthesesToken:
@@ -116,6 +305,7 @@ func matchDataSet(cursor *parsly.Cursor, columns view.ColumnIndex, column *view.
 		dataSet := matched.Text(cursor)
 		dataSetCursor := parsly.NewCursor("", []byte(dataSet[1:len(dataSet)-1]), 0)
 
+		size := 0
 		for dataSetCursor.Pos < dataSetCursor.InputSize {
 			matched = dataSetCursor.MatchAfterOptional(whitespaceMatcher, comaMatcher)
 
@@ -136,13 +326,19 @@ func matchDataSet(cursor *parsly.Cursor, columns view.ColumnIndex, column *view.
 				columnType = columnType.Elem()
 			}
 
-			if err := matchFieldValue(valueCursor, columns, columnType, column.Format, buffer, placeholders, methods); err != nil {
+			if err := p.matchFieldValue(valueCursor, columnType, column.Format, buffer); err != nil {
 				return err
 			}
 
 			if matched.Code == comaToken {
 				buffer.WriteString(", ")
 			}
+
+			size++
+		}
+
+		if rule := column.FilterRule(); rule != nil && rule.MaxInSize > 0 && size > rule.MaxInSize {
+			return NewFilterError(column.Name, "", fmt.Sprintf("column %v allows up to %v values, but got %v", column.Name, rule.MaxInSize, size))
 		}
 
 		buffer.WriteByte(')')
@@ -153,7 +349,7 @@ func matchDataSet(cursor *parsly.Cursor, columns view.ColumnIndex, column *view.
 	}
 }
 
-func matchFieldValue(cursor *parsly.Cursor, columns view.ColumnIndex, columnType reflect.Type, format string, buffer *bytes.Buffer, placeholders *[]interface{}, methods map[string]*view.Method) error {
+func (p *parser) matchFieldValue(cursor *parsly.Cursor, columnType reflect.Type, format string, buffer *bytes.Buffer) error {
 	valueCandidates, err := expressionValueCandidates(columnType)
 	if err != nil {
 		return err
@@ -164,12 +360,12 @@ func matchFieldValue(cursor *parsly.Cursor, columns view.ColumnIndex, columnType
 
 	switch matched.Code {
 	case keywordToken:
-		if _, err = columns.Lookup(value); err == nil {
-			return appendField(value, columns, columnType, buffer)
+		if _, err = p.columns.Lookup(value); err == nil {
+			return p.appendField(value, columnType, buffer)
 		}
 
-		if method, ok := methods[value]; ok {
-			return appendMethod(cursor, methods, method, columns, buffer, placeholders)
+		if method, ok := p.methods[value]; ok {
+			return p.appendMethod(cursor, method, buffer)
 		}
 
 		return fmt.Errorf("not found column or method with name %v", value)
@@ -186,7 +382,7 @@ func matchFieldValue(cursor *parsly.Cursor, columns view.ColumnIndex, columnType
 		if err != nil {
 			return err
 		}
-		*placeholders = append(*placeholders, converted)
+		p.placeholders = append(p.placeholders, converted)
 		return nil
 	default:
 		rawValue := matched.Text(cursor)
@@ -195,14 +391,14 @@ func matchFieldValue(cursor *parsly.Cursor, columns view.ColumnIndex, columnType
 			return err
 		}
 
-		*placeholders = append(*placeholders, converted)
+		p.placeholders = append(p.placeholders, converted)
 		buffer.WriteByte(' ')
 		buffer.WriteByte('?')
 		return nil
 	}
 }
 
-func appendMethod(cursor *parsly.Cursor, methods map[string]*view.Method, method *view.Method, columns view.ColumnIndex, buffer *bytes.Buffer, placeholders *[]interface{}) error {
+func (p *parser) appendMethod(cursor *parsly.Cursor, method *view.Method, buffer *bytes.Buffer) error {
 	buffer.WriteByte(' ')
 	buffer.WriteString(method.Name)
 	matched := cursor.MatchOne(parenthesesMatcher)
@@ -211,7 +407,7 @@ func appendMethod(cursor *parsly.Cursor, methods map[string]*view.Method, method
 		block := matched.Text(cursor)
 		blockCursor := parsly.NewCursor("", []byte(block[1:len(block)-1]), 0)
 		buffer.WriteByte('(')
-		if err := matchMethod(blockCursor, methods, method.Args, columns, buffer, placeholders); err != nil {
+		if err := p.matchMethod(blockCursor, method.Args, buffer); err != nil {
 			return err
 		}
 
@@ -222,7 +418,7 @@ func appendMethod(cursor *parsly.Cursor, methods map[string]*view.Method, method
 	return cursor.NewError(parenthesesMatcher)
 }
 
-func matchMethod(cursor *parsly.Cursor, methods map[string]*view.Method, args []*view.Schema, columns view.ColumnIndex, buffer *bytes.Buffer, placeholders *[]interface{}) error {
+func (p *parser) matchMethod(cursor *parsly.Cursor, args []*view.Schema, buffer *bytes.Buffer) error {
 	for i, arg := range args {
 		if i != 0 {
 			buffer.WriteString(", ")
@@ -240,7 +436,7 @@ func matchMethod(cursor *parsly.Cursor, methods map[string]*view.Method, args []
 			return cursor.NewError(comaMatcher)
 		}
 
-		if err := matchFieldValue(valueCursor, columns, arg.Type(), "", buffer, placeholders, methods); err != nil {
+		if err := p.matchFieldValue(valueCursor, arg.Type(), "", buffer); err != nil {
 			return err
 		}
 	}
@@ -254,8 +450,8 @@ func matchMethod(cursor *parsly.Cursor, methods map[string]*view.Method, args []
 	}
 }
 
-func appendField(value string, columns view.ColumnIndex, columnType reflect.Type, buffer *bytes.Buffer) error {
-	valueColumn, err := findColumn(value, columns)
+func (p *parser) appendField(value string, columnType reflect.Type, buffer *bytes.Buffer) error {
+	valueColumn, err := p.findColumn(value)
 	if err != nil {
 		return err
 	}
@@ -269,22 +465,22 @@ func appendField(value string, columns view.ColumnIndex, columnType reflect.Type
 	return nil
 }
 
-func matchColumn(cursor *parsly.Cursor, columns view.ColumnIndex) (*view.Column, error) {
+func (p *parser) matchColumn(cursor *parsly.Cursor) (*view.Column, error) {
 	candidates := []*parsly.Token{fieldMatcher}
 	matched := cursor.MatchAfterOptional(whitespaceMatcher, candidates...)
 
 	switch matched.Code {
 	case keywordToken:
 		fieldValue := matched.Text(cursor)
-		return findColumn(fieldValue, columns)
+		return p.findColumn(fieldValue)
 
 	default:
 		return nil, cursor.NewError(candidates...)
 	}
 }
 
-func findColumn(fieldName string, columns view.ColumnIndex) (*view.Column, error) {
-	lookup, err := columns.Lookup(fieldName)
+func (p *parser) findColumn(fieldName string) (*view.Column, error) {
+	lookup, err := p.columns.Lookup(fieldName)
 
 	if err != nil {
 		return nil, err
@@ -294,6 +490,7 @@ func findColumn(fieldName string, columns view.ColumnIndex) (*view.Column, error
 		return nil, fmt.Errorf("can't use %v field in expression", fieldName)
 	}
 
+	p.used = append(p.used, lookup)
 	return lookup, err
 }
 
@@ -310,6 +507,18 @@ func matchExpressionToken(cursor *parsly.Cursor, fieldType reflect.Type) (int, s
 		return 0, "", cursor.NewError(expressionTokens...)
 	case notEqualToken:
 		return matched.Code, "<>", nil
+	case notLikeToken, notIlikeToken:
+		return matched.Code, "NOT LIKE", nil
+	case ilikeToken:
+		return matched.Code, "LIKE", nil
+	case notInToken:
+		return matched.Code, "NOT IN", nil
+	case betweenToken:
+		return matched.Code, "BETWEEN", nil
+	case isNullToken:
+		return matched.Code, "IS NULL", nil
+	case isNotNullToken:
+		return matched.Code, "IS NOT NULL", nil
 	default:
 		tokenValue := matched.Text(cursor)
 		return matched.Code, tokenValue, nil
@@ -325,10 +534,10 @@ func expressionTokenCandidates(fieldType reflect.Type) ([]*parsly.Token, error)
 		return numericTokens, nil
 
 	case reflect.Bool:
-		return []*parsly.Token{notEqualMatcher, equalMatcher, inMatcher}, nil
+		return boolTokens, nil
 
 	case reflect.String:
-		return []*parsly.Token{notEqualMatcher, equalMatcher, likeMatcher, inMatcher}, nil
+		return stringTokens, nil
 
 	case reflect.Struct:
 		if fieldType == converter.TimeType {
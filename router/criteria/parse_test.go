@@ -21,6 +21,7 @@ func TestParse(t *testing.T) {
 		placeholders      []interface{}
 		expectErr         bool
 		methods           map[string]*view.Method
+		rules             map[string]*view.FilterRule
 	}{
 		{
 			description: "boolean criteria | equal true",
@@ -211,6 +212,226 @@ func TestParse(t *testing.T) {
 			sanitizedCriteria: ` foo_name like ?`,
 			placeholders:      []interface{}{"%foo%"},
 		},
+		{
+			description: "string criteria | like with escaped wildcard",
+			input:       `FooName LIKE '100\%%'`,
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` foo_name LIKE ? ESCAPE ?`,
+			placeholders:      []interface{}{`100\%%`, `\`},
+		},
+		{
+			description: "string criteria | like with invalid escape",
+			input:       `FooName like 'foo\x'`,
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			expectErr: true,
+		},
+		{
+			description: "string criteria | not like",
+			input:       "FooName not  like 'foo%'",
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` foo_name NOT LIKE ?`,
+			placeholders:      []interface{}{"foo%"},
+		},
+		{
+			description: "string criteria | ilike",
+			input:       "FooName ILIKE 'foo%'",
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` LOWER(foo_name) LIKE LOWER(?)`,
+			placeholders:      []interface{}{"foo%"},
+		},
+		{
+			description: "string criteria | not ilike with escaped wildcard",
+			input:       `FooName NOT ILIKE 'Foo\_%'`,
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` LOWER(foo_name) NOT LIKE LOWER(?) ESCAPE ?`,
+			placeholders:      []interface{}{`Foo\_%`, `\`},
+		},
+		{
+			description: "string criteria | not in",
+			input:       "FooName NOT IN ('foo', 'bar')",
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` foo_name NOT IN ( ?,  ?)`,
+			placeholders:      []interface{}{"foo", "bar"},
+		},
+		{
+			description: "string criteria | is null",
+			input:       "FooName is null",
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` foo_name IS NULL`,
+		},
+		{
+			description: "int criteria | is not null and between",
+			input:       "Id IS NOT NULL AND Id between 1 and 10",
+			columns: map[string]*view.Column{
+				"Id": {Name: "id", DataType: "int", Filterable: true},
+			},
+			sanitizedCriteria: ` id IS NOT NULL AND id BETWEEN ? AND ?`,
+			placeholders:      []interface{}{1, 10},
+		},
+		{
+			description: "int criteria | between without upper bound",
+			input:       "Id between 1",
+			columns: map[string]*view.Column{
+				"Id": {Name: "id", DataType: "int", Filterable: true},
+			},
+			expectErr: true,
+		},
+		{
+			description: "bool criteria | between is not supported",
+			input:       "IsActive between true and false",
+			columns: map[string]*view.Column{
+				"IsActive": {Name: "is_active", DataType: "bool", Filterable: true},
+			},
+			expectErr: true,
+		},
+		{
+			description: "not group",
+			input:       "NOT (Notes = 'foo' OR Id in (1,2))",
+			columns: map[string]*view.Column{
+				"Notes": {Name: "notes", DataType: "string", Filterable: true},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			sanitizedCriteria: ` NOT ( notes = ? OR id in ( ?,  ?))`,
+			placeholders:      []interface{}{"foo", 1, 2},
+		},
+		{
+			description: "not group | column starting with not",
+			input:       "Notes is null",
+			columns: map[string]*view.Column{
+				"Notes": {Name: "notes", DataType: "string", Filterable: true},
+			},
+			sanitizedCriteria: ` notes IS NULL`,
+		},
+		{
+			description: "filter rule | allowed operator",
+			input:       "Id in (1, 2)",
+			columns: map[string]*view.Column{
+				"Id": {Name: "id", DataType: "int"},
+			},
+			rules: map[string]*view.FilterRule{
+				"Id": {Column: "id", Operators: []string{"=", "in"}, MaxInSize: 2},
+			},
+			sanitizedCriteria: ` id in ( ?,  ?)`,
+			placeholders:      []interface{}{1, 2},
+		},
+		{
+			description: "filter rule | not allowed operator",
+			input:       "Id > 10",
+			columns: map[string]*view.Column{
+				"Id": {Name: "id", DataType: "int"},
+			},
+			rules: map[string]*view.FilterRule{
+				"Id": {Column: "id", Operators: []string{"=", "in"}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | too many in values",
+			input:       "Id not in (1, 2, 3)",
+			columns: map[string]*view.Column{
+				"Id": {Name: "id", DataType: "int"},
+			},
+			rules: map[string]*view.FilterRule{
+				"Id": {Column: "id", MaxInSize: 2},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | required column used",
+			input:       "Price between 1 and 10 AND Id = 1",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "float"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			sanitizedCriteria: ` price BETWEEN ? AND ? AND id = ?`,
+			placeholders:      []interface{}{1.0, 10.0, 1},
+		},
+		{
+			description: "filter rule | required column missing",
+			input:       "Price between 1 and 10",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "float"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | required column used only as right-hand value",
+			input:       "Price = Id",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "int"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | required column used only in OR branch",
+			input:       "Price between 1 and 10 OR Id = 1",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "float"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | required column used only in NOT block",
+			input:       "Price between 1 and 10 AND NOT (Id = 1)",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "float"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "filter rule | required column used in AND block",
+			input:       "(Id = 1 AND Price between 1 and 10) AND (Id = 2 OR Id = 3)",
+			columns: map[string]*view.Column{
+				"Price": {Name: "price", DataType: "float"},
+				"Id":    {Name: "id", DataType: "int", Filterable: true},
+			},
+			rules: map[string]*view.FilterRule{
+				"Price": {Column: "price", Requires: []string{"id"}},
+			},
+			sanitizedCriteria: ` ( id = ? AND price BETWEEN ? AND ?) AND ( id = ? OR id = ?)`,
+			placeholders:      []interface{}{1, 1.0, 10.0, 2, 3},
+		},
+		{
+			description: "not filterable column",
+			input:       "FooName is not null",
+			columns: map[string]*view.Column{
+				"FooName": {Name: "foo_name", DataType: "string"},
+			},
+			expectErr: true,
+		},
 
 		{
 			description: "field criteria | same type",
@@ -287,6 +508,10 @@ func TestParse(t *testing.T) {
 			}
 		}
 
+		for name, rule := range testCase.rules {
+			testCase.columns[name].SetFilterRule(rule)
+		}
+
 		parse, err := criteria.Parse(testCase.input, testCase.columns, testCase.methods)
 		if testCase.expectErr {
 			assert.NotNil(t, err, testCase.input)
@@ -296,6 +521,7 @@ func TestParse(t *testing.T) {
 		}
 
 		assert.Equal(t, testCase.sanitizedCriteria, parse.Expression, testCase.input)
+		assert.Equal(t, len(testCase.placeholders), len(parse.Placeholders), testCase.input)
 		for placeholderIndex, placeholder := range parse.Placeholders {
 			assert.Equal(t, testCase.placeholders[placeholderIndex], placeholder, testCase.input)
 		}
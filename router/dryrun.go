@@ -0,0 +1,104 @@
+package router
+
+import (
+	"context"
+	"crypto/subtle"
+	goJson "encoding/json"
+	"fmt"
+	"github.com/viant/datly/executor"
+	"github.com/viant/datly/reader"
+	"net/http"
+	"strings"
+)
+
+const (
+	DatlyDryRunHeader    = "Datly-Dry-Run"
+	DatlyDryRunKeyHeader = "Datly-Dry-Run-Key"
+	DryRunQuery          = "_dryRun"
+	DryRunExplain        = "explain"
+)
+
+type (
+	//DryRun allows route callers to preview generated SQL without committing any changes
+	DryRun struct {
+		Header string `json:",omitempty"` //header holding dry-run key, defaults to Datly-Dry-Run-Key
+		Value  string `json:",omitempty"` //dry-run key required from callers
+	}
+
+	//DryRunResponse represents dry-run response
+	DryRunResponse struct {
+		Statements []*executor.StatementResult `json:",omitempty"`
+		Plan       *reader.Plan                `json:",omitempty"`
+	}
+)
+
+//Init initialises DryRun, Value is required
+func (d *DryRun) Init() error {
+	if d.Value == "" {
+		return fmt.Errorf("dry-run Value was empty")
+	}
+
+	if d.Header == "" {
+		d.Header = DatlyDryRunKeyHeader
+	}
+
+	return nil
+}
+
+//authorized returns true if request holds dry-run key
+func (d *DryRun) authorized(request *http.Request) bool {
+	if d.Value == "" {
+		return false
+	}
+
+	header := d.Header
+	if header == "" {
+		header = DatlyDryRunKeyHeader
+	}
+
+	return subtle.ConstantTimeCompare([]byte(request.Header.Get(header)), []byte(d.Value)) == 1
+}
+
+//dryRunMode returns requested dry-run mode, empty string if dry-run was not requested
+func (r *Route) dryRunMode(request *http.Request) (string, error) {
+	mode := request.Header.Get(DatlyDryRunHeader)
+	if mode == "" {
+		mode = request.URL.Query().Get(DryRunQuery)
+	}
+
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" || mode == "false" {
+		return "", nil
+	}
+
+	if r.DryRun == nil {
+		return "", fmt.Errorf("dry-run is not enabled for route %v %v", r.Method, r.URI)
+	}
+
+	if !r.DryRun.authorized(request) {
+		return "", fmt.Errorf("unauthorized dry-run request")
+	}
+
+	return mode, nil
+}
+
+func (r *Router) writeReaderDryRun(ctx context.Context, session *ReaderSession, mode string) {
+	readerSession := reader.NewSession(nil, session.Route.View)
+	readerSession.Selectors = session.Selectors
+
+	plan, err := reader.New().Plan(ctx, readerSession, mode == DryRunExplain)
+	if err != nil {
+		r.writeErr(session.Response, session.Route, err, http.StatusBadRequest)
+		return
+	}
+
+	data, err := goJson.Marshal(&DryRunResponse{Plan: plan})
+	if err != nil {
+		r.writeErr(session.Response, session.Route, err, http.StatusInternalServerError)
+		return
+	}
+
+	session.Response.Header().Set(HeaderContentType, JSONFormat)
+	session.Response.WriteHeader(http.StatusOK)
+	_, _ = session.Response.Write(data)
+}
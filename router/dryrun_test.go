@@ -0,0 +1,94 @@
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_DryRunMode(t *testing.T) {
+	testcases := []struct {
+		description string
+		dryRun      *DryRun
+		URL         string
+		headers     map[string]string
+		expect      string
+		expectErr   bool
+	}{
+		{
+			description: "not requested",
+			dryRun:      &DryRun{},
+			URL:         "/events",
+		},
+		{
+			description: "query param",
+			dryRun:      &DryRun{Value: "secret"},
+			URL:         "/events?_dryRun=true",
+			headers:     map[string]string{DatlyDryRunKeyHeader: "secret"},
+			expect:      "true",
+		},
+		{
+			description: "header with explain",
+			dryRun:      &DryRun{Value: "secret"},
+			URL:         "/events",
+			headers:     map[string]string{DatlyDryRunHeader: "Explain", DatlyDryRunKeyHeader: "secret"},
+			expect:      DryRunExplain,
+		},
+		{
+			description: "empty key",
+			dryRun:      &DryRun{},
+			URL:         "/events?_dryRun=true",
+			expectErr:   true,
+		},
+		{
+			description: "invalid key",
+			dryRun:      &DryRun{Value: "secret"},
+			URL:         "/events?_dryRun=true",
+			headers:     map[string]string{DatlyDryRunKeyHeader: "secret1"},
+			expectErr:   true,
+		},
+		{
+			description: "dry-run disabled",
+			URL:         "/events?_dryRun=true",
+			expectErr:   true,
+		},
+		{
+			description: "missing key",
+			dryRun:      &DryRun{Value: "secret"},
+			URL:         "/events?_dryRun=true",
+			expectErr:   true,
+		},
+		{
+			description: "default key header",
+			dryRun:      &DryRun{Value: "secret"},
+			URL:         "/events?_dryRun=true",
+			headers:     map[string]string{DatlyDryRunKeyHeader: "secret"},
+			expect:      "true",
+		},
+		{
+			description: "custom key header",
+			dryRun:      &DryRun{Header: "X-Debug-Key", Value: "secret"},
+			URL:         "/events?_dryRun=true",
+			headers:     map[string]string{"X-Debug-Key": "secret"},
+			expect:      "true",
+		},
+	}
+
+	for _, testcase := range testcases {
+		route := &Route{DryRun: testcase.dryRun}
+		request := httptest.NewRequest(http.MethodGet, testcase.URL, nil)
+		for key, value := range testcase.headers {
+			request.Header.Set(key, value)
+		}
+
+		mode, err := route.dryRunMode(request)
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			continue
+		}
+
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, testcase.expect, mode, testcase.description)
+	}
+}
@@ -2,16 +2,26 @@ package router
 
 import (
 	"context"
+	goJson "encoding/json"
+	"errors"
 	"github.com/viant/datly/executor"
 	"net/http"
+	"strings"
+	"unsafe"
 )
 
 func (r *Router) executorHandler(route *Route) viewHandler {
 	return func(response http.ResponseWriter, request *http.Request) {
-		body, err := r.executorHandlerWithError(route, request)
+		dryRun, err := route.dryRunMode(request)
+		if err != nil {
+			r.writeErr(response, route, err, http.StatusForbidden)
+			return
+		}
+
+		body, err := r.executorHandlerWithError(route, request, dryRun != "")
 
 		if err != nil {
-			r.writeErr(response, route, err, 400)
+			r.writeErr(response, route, err, execErrorStatus(err))
 			return
 		}
 
@@ -22,7 +32,7 @@ func (r *Router) executorHandler(route *Route) viewHandler {
 	}
 }
 
-func (r *Router) executorHandlerWithError(route *Route, request *http.Request) ([]byte, error) {
+func (r *Router) executorHandlerWithError(route *Route, request *http.Request, dryRun bool) ([]byte, error) {
 	ctx := context.Background()
 
 	parameters, err := NewRequestParameters(request, route)
@@ -40,19 +50,32 @@ func (r *Router) executorHandlerWithError(route *Route, request *http.Request) (
 		return nil, err
 	}
 
+	session.Mode = route.ExecMode
+	session.TxOptions = route.TxOptions
+	session.DryRun = dryRun
+	session.Expected = expectedVersion(request)
+	session.BatchSize = route.BatchSize
 	anExecutor := executor.New()
 
 	err = anExecutor.Exec(ctx, session)
-	if err != nil || route.ResponseBody == nil {
+	if err != nil {
 		return nil, err
 	}
 
+	if dryRun {
+		return goJson.Marshal(&DryRunResponse{Statements: session.Results})
+	}
+
+	if route.ResponseBody == nil {
+		return nil, nil
+	}
+
 	body, err := route.execResponseBody(parameters, session)
 	if err != nil {
 		return nil, err
 	}
 
-	responseBody := r.wrapWithResponseIfNeeded(body, route, nil, nil)
+	responseBody := r.wrapExecResponse(body, route, session)
 	return route._outputMarshaller.Marshal(responseBody, nil)
 }
 
@@ -63,3 +86,40 @@ func (r *Route) execResponseBody(parameters *RequestParams, session *executor.Se
 
 	return parameters.requestBody, nil
 }
+
+func (r *Router) wrapExecResponse(body interface{}, route *Route, session *executor.Session) interface{} {
+	if route._responseSetter == nil {
+		return body
+	}
+
+	response := r.newResponse(body, route, nil, nil, nil)
+	if resultField := route._responseSetter.resultField; resultField != nil {
+		resultField.SetValue(unsafe.Pointer(response.Pointer()), session.Result())
+	}
+
+	return response.Elem().Interface()
+}
+
+func execErrorStatus(err error) int {
+	var conflictErr *executor.ConflictError
+	if errors.As(err, &conflictErr) {
+		return http.StatusConflict
+	}
+
+	var partialErr *executor.PartialCommitError
+	if errors.As(err, &partialErr) {
+		return http.StatusInternalServerError
+	}
+
+	return http.StatusBadRequest
+}
+
+//expectedVersion returns optimistic lock expected value from If-Match header
+func expectedVersion(request *http.Request) string {
+	ifMatch := strings.TrimSpace(request.Header.Get(HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return ""
+	}
+
+	return strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+}
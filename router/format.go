@@ -0,0 +1,123 @@
+package router
+
+import (
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/arrow"
+	"github.com/viant/datly/router/marshal/json"
+	"github.com/viant/datly/router/marshal/parquet"
+	"github.com/viant/datly/router/marshal/xml"
+	"github.com/viant/datly/router/marshal/yaml"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	XMLQueryFormat     = "xml"
+	XMLFormat          = "application/xml"
+	YAMLQueryFormat    = "yaml"
+	YAMLFormat         = "application/yaml"
+	ArrowQueryFormat   = "arrow"
+	ArrowFormat        = "application/vnd.apache.arrow.stream"
+	ParquetQueryFormat = "parquet"
+	ParquetFormat      = "application/vnd.apache.parquet"
+)
+
+var formats = &FormatRegistry{index: map[string]*Format{}}
+
+type (
+	//FormatMarshaller marshals route response in the registered Format
+	FormatMarshaller interface {
+		Marshal(value interface{}, filters *json.Filters) ([]byte, error)
+	}
+
+	//FormatMarshallerFn creates FormatMarshaller for the route response type and output config
+	FormatMarshallerFn func(rType reflect.Type, config marshal.Default) (FormatMarshaller, error)
+
+	//Format represents pluggable response format, selected with _format query param or Accept header
+	Format struct {
+		Name          string //_format query param value, i.e. xml
+		ContentType   string //response Content-Type, i.e. application/xml
+		NewMarshaller FormatMarshallerFn
+	}
+
+	//FormatRegistry represents Format registry, keyed by Format name
+	FormatRegistry struct {
+		index map[string]*Format
+		names []string
+		mux   sync.RWMutex
+	}
+)
+
+//Register registers Format
+func (r *FormatRegistry) Register(format *Format) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	name := strings.ToLower(format.Name)
+	if _, ok := r.index[name]; !ok {
+		r.names = append(r.names, name)
+	}
+
+	r.index[name] = format
+}
+
+//Lookup returns Format with given name
+func (r *FormatRegistry) Lookup(name string) (*Format, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	format, ok := r.index[strings.ToLower(name)]
+	return format, ok
+}
+
+//LookupContentType returns Format with given content type
+func (r *FormatRegistry) LookupContentType(contentType string) (*Format, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	for _, format := range r.index {
+		if strings.EqualFold(format.ContentType, contentType) {
+			return format, true
+		}
+	}
+
+	return nil, false
+}
+
+//Formats returns registered formats in the registration order
+func (r *FormatRegistry) Formats() []*Format {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	result := make([]*Format, 0, len(r.names))
+	for _, name := range r.names {
+		result = append(result, r.index[name])
+	}
+
+	return result
+}
+
+//RegisterFormat registers Format in the default registry, it has to be called before routes are initialized
+func RegisterFormat(format *Format) {
+	formats.Register(format)
+}
+
+//LookupFormat returns Format from the default registry
+func LookupFormat(name string) (*Format, bool) {
+	return formats.Lookup(name)
+}
+
+func init() {
+	RegisterFormat(&Format{Name: XMLQueryFormat, ContentType: XMLFormat, NewMarshaller: func(rType reflect.Type, config marshal.Default) (FormatMarshaller, error) {
+		return xml.New(rType, config)
+	}})
+
+	RegisterFormat(&Format{Name: YAMLQueryFormat, ContentType: YAMLFormat, NewMarshaller: func(rType reflect.Type, config marshal.Default) (FormatMarshaller, error) {
+		return yaml.New(rType, config)
+	}})
+
+	RegisterFormat(&Format{Name: ArrowQueryFormat, ContentType: ArrowFormat, NewMarshaller: func(rType reflect.Type, config marshal.Default) (FormatMarshaller, error) {
+		return arrow.New(rType, config)
+	}})
+
+	RegisterFormat(&Format{Name: ParquetQueryFormat, ContentType: ParquetFormat, NewMarshaller: func(rType reflect.Type, config marshal.Default) (FormatMarshaller, error) {
+		return parquet.New(rType, config)
+	}})
+}
@@ -0,0 +1,286 @@
+package arrow
+
+import (
+	"bytes"
+	goJson "encoding/json"
+	"fmt"
+	goArrow "github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/ipc"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	//Marshaller marshals values as Apache Arrow IPC stream, it shares Exclude, CaseFormat, OmitEmpty and DateLayout with JSON marshaller.
+	//Array of objects, or the first array field of an object, i.e. wrapped response data, is represented as a record batch.
+	//Column types are inferred from values, nested objects and arrays are represented as JSON text columns.
+	Marshaller struct {
+		json *json.Marshaller
+	}
+
+	column struct {
+		name   string
+		kind   kind
+		values []goJson.RawMessage
+	}
+
+	kind int
+)
+
+const (
+	nullKind kind = iota
+	boolKind
+	intKind
+	floatKind
+	stringKind
+)
+
+//New creates Arrow Marshaller
+func New(rType reflect.Type, config marshal.Default) (*Marshaller, error) {
+	jsonMarshaller, err := json.New(rType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Marshaller{json: jsonMarshaller}, nil
+}
+
+//Marshal marshals value as Arrow IPC stream with a single record batch
+func (m *Marshaller) Marshal(value interface{}, filters *json.Filters) ([]byte, error) {
+	data, err := m.json.Marshal(value, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := FromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	defer record.Release()
+
+	buffer := &bytes.Buffer{}
+	writer := ipc.NewWriter(buffer, ipc.WithSchema(record.Schema()))
+	if err = writer.Write(record); err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+//FromJSON converts JSON document to Arrow record, columns are ordered as the first occurrence of object fields
+//record has to be released by the caller
+func FromJSON(data []byte) (goArrow.Record, error) {
+	rows, err := jsonRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []*column
+	index := map[string]*column{}
+	for i, row := range rows {
+		names, values, err := decodeObject(row)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			aColumn, ok := index[name]
+			if !ok {
+				aColumn = &column{name: name, values: make([]goJson.RawMessage, len(rows))}
+				index[name] = aColumn
+				columns = append(columns, aColumn)
+			}
+
+			aColumn.values[i] = values[name]
+			aColumn.kind = aColumn.kind.merge(valueKind(values[name]))
+		}
+	}
+
+	fields := make([]goArrow.Field, 0, len(columns))
+	for _, aColumn := range columns {
+		fields = append(fields, goArrow.Field{Name: aColumn.name, Type: aColumn.kind.dataType(), Nullable: true})
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, goArrow.NewSchema(fields, nil))
+	defer builder.Release()
+	for i, aColumn := range columns {
+		if err = aColumn.append(builder.Field(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.NewRecord(), nil
+}
+
+//jsonRows returns array elements, the first array field elements of an object, or the object itself
+func jsonRows(data []byte) ([]goJson.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	switch data[0] {
+	case '[':
+		var rows []goJson.RawMessage
+		return rows, goJson.Unmarshal(data, &rows)
+	case '{':
+		names, values, err := decodeObject(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			if value := bytes.TrimSpace(values[name]); len(value) > 0 && value[0] == '[' {
+				return jsonRows(value)
+			}
+		}
+
+		return []goJson.RawMessage{data}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported columnar value: %s", data)
+}
+
+//decodeObject returns object field names in the document order and field values
+func decodeObject(data []byte) ([]string, map[string]goJson.RawMessage, error) {
+	decoder := goJson.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if token != goJson.Delim('{') {
+		return nil, nil, fmt.Errorf("unsupported columnar row: %s", data)
+	}
+
+	var names []string
+	values := map[string]goJson.RawMessage{}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		name, ok := key.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected object key %v", key)
+		}
+
+		var value goJson.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok = values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = value
+	}
+
+	return names, values, nil
+}
+
+func valueKind(value goJson.RawMessage) kind {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 || string(value) == "null" {
+		return nullKind
+	}
+
+	switch value[0] {
+	case 't', 'f':
+		return boolKind
+	case '"', '{', '[':
+		return stringKind
+	}
+
+	if _, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+		return intKind
+	}
+
+	return floatKind
+}
+
+func (k kind) merge(other kind) kind {
+	switch {
+	case k == other || other == nullKind:
+		return k
+	case k == nullKind:
+		return other
+	case (k == intKind && other == floatKind) || (k == floatKind && other == intKind):
+		return floatKind
+	}
+
+	return stringKind
+}
+
+func (k kind) dataType() goArrow.DataType {
+	switch k {
+	case boolKind:
+		return goArrow.FixedWidthTypes.Boolean
+	case intKind:
+		return goArrow.PrimitiveTypes.Int64
+	case floatKind:
+		return goArrow.PrimitiveTypes.Float64
+	}
+
+	return goArrow.BinaryTypes.String
+}
+
+func (c *column) append(builder array.Builder) error {
+	for _, value := range c.values {
+		value = bytes.TrimSpace(value)
+		if valueKind(value) == nullKind {
+			builder.AppendNull()
+			continue
+		}
+
+		var err error
+		switch actual := builder.(type) {
+		case *array.BooleanBuilder:
+			actual.Append(string(value) == "true")
+		case *array.Int64Builder:
+			var intValue int64
+			if intValue, err = strconv.ParseInt(string(value), 10, 64); err == nil {
+				actual.Append(intValue)
+			}
+		case *array.Float64Builder:
+			var floatValue float64
+			if floatValue, err = strconv.ParseFloat(string(value), 64); err == nil {
+				actual.Append(floatValue)
+			}
+		case *array.StringBuilder:
+			actual.Append(textValue(value))
+		default:
+			err = fmt.Errorf("unsupported column %v builder %T", c.name, builder)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid column %v value %s: %w", c.name, value, err)
+		}
+	}
+
+	return nil
+}
+
+//textValue returns unquoted JSON string, or JSON text of other values
+func textValue(value goJson.RawMessage) string {
+	if value[0] != '"' {
+		return string(value)
+	}
+
+	var text string
+	if err := goJson.Unmarshal(value, &text); err != nil {
+		return strings.Trim(string(value), `"`)
+	}
+
+	return text
+}
@@ -0,0 +1,169 @@
+package arrow_test
+
+import (
+	"bytes"
+	goArrow "github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/ipc"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/arrow"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMarshaller_Marshal(t *testing.T) {
+	type Foo struct {
+		ID      int
+		Name    string
+		Price   float64
+		Active  bool
+		Comment *string
+		Tags    []string
+	}
+
+	type Response struct {
+		Status string
+		Data   []*Foo
+	}
+
+	comment := "abc"
+	testcases := []struct {
+		description string
+		rType       reflect.Type
+		value       interface{}
+		config      marshal.Default
+		expect      string
+	}{
+		{
+			description: "slice",
+			rType:       reflect.TypeOf(&Foo{}),
+			value:       []*Foo{{ID: 1, Name: "a", Price: 1.5, Active: true, Tags: []string{"x"}}, {ID: 2, Name: "b", Price: 2, Comment: &comment}},
+			expect: `record:
+  schema:
+  fields: 6
+    - ID: type=int64, nullable
+    - Name: type=utf8, nullable
+    - Price: type=float64, nullable
+    - Active: type=bool, nullable
+    - Comment: type=utf8, nullable
+    - Tags: type=utf8, nullable
+  rows: 2
+  col[0][ID]: [1 2]
+  col[1][Name]: ["a" "b"]
+  col[2][Price]: [1.5 2]
+  col[3][Active]: [true false]
+  col[4][Comment]: [(null) "abc"]
+  col[5][Tags]: ["[\"x\"]" "[]"]
+`,
+		},
+		{
+			description: "wrapped response with exclude",
+			rType:       reflect.TypeOf(&Response{}),
+			value:       &Response{Status: "ok", Data: []*Foo{{ID: 3, Name: "c"}}},
+			config:      marshal.Default{Exclude: map[string]bool{"Data.Price": true, "Data.Active": true, "Data.Comment": true, "Data.Tags": true}},
+			expect: `record:
+  schema:
+  fields: 2
+    - ID: type=int64, nullable
+    - Name: type=utf8, nullable
+  rows: 1
+  col[0][ID]: [3]
+  col[1][Name]: ["c"]
+`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		marshaller, err := arrow.New(testcase.rType, testcase.config)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		actual, err := marshaller.Marshal(testcase.value, nil)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		reader, err := ipc.NewReader(bytes.NewReader(actual))
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		if assert.True(t, reader.Next(), testcase.description) {
+			assert.Equal(t, testcase.expect, recordString(reader.Record()), testcase.description)
+		}
+		reader.Release()
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	testcases := []struct {
+		description string
+		JSON        string
+		expectRows  int64
+		expectCols  []string
+		expectErr   bool
+	}{
+		{
+			description: "empty array",
+			JSON:        `[]`,
+		},
+		{
+			description: "single object",
+			JSON:        `{"ID":1,"Name":"a"}`,
+			expectRows:  1,
+			expectCols:  []string{"ID", "Name"},
+		},
+		{
+			description: "mixed numbers and sparse fields",
+			JSON:        `[{"ID":1},{"ID":1.5,"Name":"b"}]`,
+			expectRows:  2,
+			expectCols:  []string{"ID", "Name"},
+		},
+		{
+			description: "scalar",
+			JSON:        `1`,
+			expectErr:   true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		record, err := arrow.FromJSON([]byte(testcase.JSON))
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			continue
+		}
+
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expectRows, record.NumRows(), testcase.description)
+		var columns []string
+		for _, field := range record.Schema().Fields() {
+			columns = append(columns, field.Name)
+		}
+		assert.Equal(t, testcase.expectCols, columns, testcase.description)
+		record.Release()
+	}
+}
+
+func recordString(record goArrow.Record) string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("record:\n  schema:\n")
+	buffer.WriteString("  fields: " + strconv.Itoa(len(record.Schema().Fields())) + "\n")
+	for _, field := range record.Schema().Fields() {
+		buffer.WriteString("    - " + field.Name + ": type=" + field.Type.String())
+		if field.Nullable {
+			buffer.WriteString(", nullable")
+		}
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("  rows: " + strconv.Itoa(int(record.NumRows())) + "\n")
+	for i, col := range record.Columns() {
+		buffer.WriteString("  col[" + strconv.Itoa(i) + "][" + record.ColumnName(i) + "]: " + col.String() + "\n")
+	}
+
+	return buffer.String()
+}
@@ -335,6 +335,9 @@ func (f *fieldMarshaller) updateFieldMarshaller(parentType reflect.Type, config
 	case reflect.Interface:
 		f.updateInterfaceMarshaller(config, j)
 
+	case reflect.Map:
+		updateMapMarshaller(f)
+
 	default:
 		return fmt.Errorf("unsupported type %v", parentType.String())
 	}
@@ -342,6 +345,19 @@ func (f *fieldMarshaller) updateFieldMarshaller(parentType reflect.Type, config
 	return nil
 }
 
+//updateMapMarshaller marshals maps with encoding/json
+func updateMapMarshaller(f *fieldMarshaller) {
+	f.marshall = func(_ reflect.Type, ptr unsafe.Pointer, sb *bytes.Buffer, _ *Filters) error {
+		data, err := goJson.Marshal(reflect.NewAt(f.xField.Type, f.xField.Pointer(ptr)).Elem().Interface())
+		if err != nil {
+			return err
+		}
+
+		sb.Write(data)
+		return nil
+	}
+}
+
 func (f *fieldMarshaller) updateInterfaceMarshaller(config marshal.Default, j *Marshaller) {
 	f.marshall = func(interfaceType reflect.Type, ptr unsafe.Pointer, buffer *bytes.Buffer, filters *Filters) error {
 		asInterface := f.xField.Value(ptr)
@@ -0,0 +1,78 @@
+package json
+
+import (
+	"fmt"
+	"github.com/viant/xunsafe"
+	"io"
+	"reflect"
+)
+
+//Stream writes records incrementally, either as JSON array or new line delimited JSON
+type Stream struct {
+	marshaller *Marshaller
+	writer     io.Writer
+	filters    *Filters
+	delimited  bool
+	counter    int
+}
+
+//NewStream creates Stream writing Marshaller type records to the writer
+func (j *Marshaller) NewStream(writer io.Writer, filters *Filters, delimited bool) *Stream {
+	return &Stream{
+		marshaller: j,
+		writer:     writer,
+		filters:    filters,
+		delimited:  delimited,
+	}
+}
+
+//Write marshals and writes single record
+func (s *Stream) Write(record interface{}) error {
+	rType := reflect.TypeOf(record)
+	if rType != s.marshaller.rType {
+		return fmt.Errorf("type missmatch, wanted %v but got %v", s.marshaller.rType.String(), rType.String())
+	}
+
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	buffer := bufferPool.Get()
+	defer bufferPool.Put(buffer)
+
+	if !s.delimited {
+		if s.counter == 0 {
+			buffer.WriteByte('[')
+		} else {
+			buffer.WriteByte(',')
+		}
+	}
+
+	if err := s.marshaller.stringifyValue(rType, xunsafe.AsPointer(record), s.filters, rType, buffer, ""); err != nil {
+		return err
+	}
+
+	if s.delimited {
+		buffer.WriteByte('\n')
+	}
+
+	s.counter++
+	_, err := s.writer.Write(buffer.Bytes())
+	return err
+}
+
+//Close completes JSON array, it does not close underlying writer
+func (s *Stream) Close() error {
+	if s.delimited {
+		return nil
+	}
+
+	var err error
+	if s.counter == 0 {
+		_, err = s.writer.Write([]byte("[]"))
+	} else {
+		_, err = s.writer.Write([]byte("]"))
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+package json_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/json"
+	"reflect"
+	"testing"
+)
+
+func TestStream_Write(t *testing.T) {
+	type Foo struct {
+		ID   int
+		Name string
+	}
+
+	testcases := []struct {
+		description string
+		records     []*Foo
+		delimited   bool
+		expect      string
+	}{
+		{
+			description: "json array",
+			records:     []*Foo{{ID: 1, Name: "abc"}, {ID: 2, Name: "def"}},
+			expect:      `[{"ID":1,"Name":"abc"},{"ID":2,"Name":"def"}]`,
+		},
+		{
+			description: "empty json array",
+			expect:      `[]`,
+		},
+		{
+			description: "new line delimited",
+			records:     []*Foo{{ID: 1, Name: "abc"}, {ID: 2, Name: "def"}},
+			delimited:   true,
+			expect:      "{\"ID\":1,\"Name\":\"abc\"}\n{\"ID\":2,\"Name\":\"def\"}\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		marshaller, err := json.New(reflect.TypeOf(&Foo{}), marshal.Default{})
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		buffer := &bytes.Buffer{}
+		stream := marshaller.NewStream(buffer, nil, testcase.delimited)
+		for _, record := range testcase.records {
+			assert.Nil(t, stream.Write(record), testcase.description)
+		}
+
+		assert.Nil(t, stream.Close(), testcase.description)
+		assert.Equal(t, testcase.expect, buffer.String(), testcase.description)
+	}
+}
@@ -0,0 +1,57 @@
+package parquet
+
+import (
+	"bytes"
+	goParquet "github.com/apache/arrow/go/v10/parquet"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/arrow"
+	"github.com/viant/datly/router/marshal/json"
+	"reflect"
+)
+
+//Marshaller marshals values as Apache Parquet file, it shares Exclude, CaseFormat, OmitEmpty and DateLayout with JSON marshaller.
+//Rows and column types are resolved the same way as with Arrow marshaller.
+type Marshaller struct {
+	json *json.Marshaller
+}
+
+//New creates Parquet Marshaller
+func New(rType reflect.Type, config marshal.Default) (*Marshaller, error) {
+	jsonMarshaller, err := json.New(rType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Marshaller{json: jsonMarshaller}, nil
+}
+
+//Marshal marshals value as Parquet file with a single row group
+func (m *Marshaller) Marshal(value interface{}, filters *json.Filters) ([]byte, error) {
+	data, err := m.json.Marshal(value, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := arrow.FromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	defer record.Release()
+
+	buffer := &bytes.Buffer{}
+	writer, err := pqarrow.NewFileWriter(record.Schema(), buffer, goParquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = writer.Write(record); err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,75 @@
+package parquet_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	goParquet "github.com/apache/arrow/go/v10/parquet"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/parquet"
+	"reflect"
+	"testing"
+)
+
+func TestMarshaller_Marshal(t *testing.T) {
+	type Foo struct {
+		ID    int
+		Name  string
+		Price *float64
+	}
+
+	price := 1.5
+	testcases := []struct {
+		description  string
+		value        interface{}
+		config       marshal.Default
+		expectRows   int64
+		expectFields []string
+		expectTypes  []string
+	}{
+		{
+			description:  "slice",
+			value:        []*Foo{{ID: 1, Name: "a", Price: &price}, {ID: 2, Name: "b"}},
+			expectRows:   2,
+			expectFields: []string{"ID", "Name", "Price"},
+			expectTypes:  []string{"int64", "utf8", "float64"},
+		},
+		{
+			description:  "exclude",
+			value:        []*Foo{{ID: 3, Name: "c"}},
+			config:       marshal.Default{Exclude: map[string]bool{"Price": true}},
+			expectRows:   1,
+			expectFields: []string{"ID", "Name"},
+			expectTypes:  []string{"int64", "utf8"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		marshaller, err := parquet.New(reflect.TypeOf(&Foo{}), testcase.config)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		actual, err := marshaller.Marshal(testcase.value, nil)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(actual), goParquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expectRows, table.NumRows(), testcase.description)
+		var fields, types []string
+		for _, field := range table.Schema().Fields() {
+			fields = append(fields, field.Name)
+			types = append(types, field.Type.String())
+		}
+		assert.Equal(t, testcase.expectFields, fields, testcase.description)
+		assert.Equal(t, testcase.expectTypes, types, testcase.description)
+		table.Release()
+	}
+}
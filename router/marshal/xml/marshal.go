@@ -0,0 +1,149 @@
+package xml
+
+import (
+	"bytes"
+	goJson "encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/json"
+	"io"
+	"reflect"
+	"unicode"
+)
+
+const (
+	RootElement  = "result"
+	ItemElement  = "item"
+	EntryElement = "entry"
+	KeyAttribute = "key"
+)
+
+//Marshaller marshals values as XML, it shares Exclude, CaseFormat, OmitEmpty and DateLayout with JSON marshaller.
+//Objects are represented as elements named after their fields, arrays as repeated item elements.
+//Fields that are not valid XML names, i.e. map keys with spaces, are represented as entry elements with key attribute.
+type Marshaller struct {
+	json *json.Marshaller
+}
+
+//New creates XML Marshaller
+func New(rType reflect.Type, config marshal.Default) (*Marshaller, error) {
+	jsonMarshaller, err := json.New(rType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Marshaller{json: jsonMarshaller}, nil
+}
+
+//Marshal marshals value as XML document
+func (m *Marshaller) Marshal(value interface{}, filters *json.Filters) ([]byte, error) {
+	data, err := m.json.Marshal(value, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJSON(data)
+}
+
+//FromJSON converts JSON document to XML, preserving object fields order
+func FromJSON(data []byte) ([]byte, error) {
+	decoder := goJson.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(xml.Header)
+	if err := writeElement(decoder, buffer, RootElement, ""); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeElement(decoder *goJson.Decoder, buffer *bytes.Buffer, name string, key string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	buffer.WriteString("<" + name)
+	if key != "" {
+		buffer.WriteString(" " + KeyAttribute + `="`)
+		if err = xml.EscapeText(buffer, []byte(key)); err != nil {
+			return err
+		}
+		buffer.WriteString(`"`)
+	}
+
+	if token == nil {
+		buffer.WriteString("/>")
+		return nil
+	}
+
+	buffer.WriteString(">")
+	switch actual := token.(type) {
+	case goJson.Delim:
+		switch actual {
+		case '{':
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+
+				keyName, ok := key.(string)
+				if !ok {
+					return fmt.Errorf("unexpected object key %v", key)
+				}
+
+				elementName, elementKey := keyName, ""
+				if !isName(keyName) {
+					elementName, elementKey = EntryElement, keyName
+				}
+
+				if err = writeElement(decoder, buffer, elementName, elementKey); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for decoder.More() {
+				if err = writeElement(decoder, buffer, ItemElement, ""); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unexpected delimiter %v", actual)
+		}
+
+		if _, err = decoder.Token(); err != nil && err != io.EOF {
+			return err
+		}
+	case string:
+		if err = xml.EscapeText(buffer, []byte(actual)); err != nil {
+			return err
+		}
+	default:
+		buffer.WriteString(fmt.Sprintf("%v", actual))
+	}
+
+	buffer.WriteString("</" + name + ">")
+	return nil
+}
+
+//isName returns true if key can be used as XML element name
+func isName(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for i, r := range key {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
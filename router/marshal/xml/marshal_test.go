@@ -0,0 +1,79 @@
+package xml_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMarshaller_Marshal(t *testing.T) {
+	type Foo struct {
+		ID      int
+		Name    string
+		Comment *string
+		Tags    []string
+	}
+
+	testcases := []struct {
+		description string
+		value       interface{}
+		config      marshal.Default
+		expect      string
+	}{
+		{
+			description: "slice",
+			value:       []*Foo{{ID: 1, Name: "a<b", Tags: []string{"x", "y"}}},
+			expect:      `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<result><item><ID>1</ID><Name>a&lt;b</Name><Comment/><Tags><item>x</item><item>y</item></Tags></item></result>`,
+		},
+		{
+			description: "exclude and omit empty",
+			value:       &Foo{ID: 2, Name: "abc"},
+			config:      marshal.Default{OmitEmpty: true, Exclude: map[string]bool{"Name": true}},
+			expect:      `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<result><ID>2</ID><Comment/></result>`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		marshaller, err := xml.New(reflect.TypeOf(&Foo{}), testcase.config)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		actual, err := marshaller.Marshal(testcase.value, nil)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, string(actual), testcase.description)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	testcases := []struct {
+		description string
+		JSON        string
+		expect      string
+	}{
+		{
+			description: "valid names",
+			JSON:        `{"ID":1,"first_name":"a","v1.2":"b"}`,
+			expect:      `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<result><ID>1</ID><first_name>a</first_name><v1.2>b</v1.2></result>`,
+		},
+		{
+			description: "invalid names",
+			JSON:        `{"first name":"a","1st":"b","a<b&c":null,"\"q\"":{"x":1}}`,
+			expect:      `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<result><entry key="first name">a</entry><entry key="1st">b</entry><entry key="a&lt;b&amp;c"/><entry key="&#34;q&#34;"><x>1</x></entry></result>`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		actual, err := xml.FromJSON([]byte(testcase.JSON))
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, string(actual), testcase.description)
+	}
+}
@@ -0,0 +1,51 @@
+package yaml
+
+import (
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/json"
+	"gopkg.in/yaml.v3"
+	"reflect"
+)
+
+//Marshaller marshals values as YAML, it shares Exclude, CaseFormat, OmitEmpty and DateLayout with JSON marshaller
+type Marshaller struct {
+	json *json.Marshaller
+}
+
+//New creates YAML Marshaller
+func New(rType reflect.Type, config marshal.Default) (*Marshaller, error) {
+	jsonMarshaller, err := json.New(rType, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Marshaller{json: jsonMarshaller}, nil
+}
+
+//Marshal marshals value as YAML document
+func (m *Marshaller) Marshal(value interface{}, filters *json.Filters) ([]byte, error) {
+	data, err := m.json.Marshal(value, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJSON(data)
+}
+
+//FromJSON converts JSON document to block style YAML, preserving object fields order
+func FromJSON(data []byte) ([]byte, error) {
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+
+	resetStyle(node)
+	return yaml.Marshal(node)
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
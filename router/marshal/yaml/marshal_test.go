@@ -0,0 +1,50 @@
+package yaml_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/router/marshal"
+	"github.com/viant/datly/router/marshal/yaml"
+	"reflect"
+	"testing"
+)
+
+func TestMarshaller_Marshal(t *testing.T) {
+	type Foo struct {
+		ID      int
+		Name    string
+		Comment *string
+	}
+
+	testcases := []struct {
+		description string
+		value       interface{}
+		config      marshal.Default
+		expect      string
+	}{
+		{
+			description: "slice",
+			value:       []*Foo{{ID: 1, Name: "true"}, {ID: 2, Name: "abc"}},
+			expect:      "- ID: 1\n  Name: \"true\"\n  Comment: null\n- ID: 2\n  Name: abc\n  Comment: null\n",
+		},
+		{
+			description: "exclude and omit empty",
+			value:       &Foo{ID: 2, Name: "abc"},
+			config:      marshal.Default{OmitEmpty: true, Exclude: map[string]bool{"Name": true}},
+			expect:      "ID: 2\nComment: null\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		marshaller, err := yaml.New(reflect.TypeOf(&Foo{}), testcase.config)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		actual, err := marshaller.Marshal(testcase.value, nil)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, string(actual), testcase.description)
+	}
+}
@@ -0,0 +1,168 @@
+package router
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const anyMediaType = "*"
+
+type mediaRange struct {
+	mainType string
+	subType  string
+	quality  float64
+}
+
+//NotAcceptableErr returns error for Accept header that none of the route formats satisfies
+func NotAcceptableErr(accept string) error {
+	return fmt.Errorf("none of the accepted formats %v is supported", accept)
+}
+
+func (r *Route) initDefaultFormat() error {
+	if r.DefaultFormat == "" {
+		r._defaultFormat = JSONFormat
+		return nil
+	}
+
+	contentType, ok := formatContentType(r.DefaultFormat)
+	if !ok || !r.supportsFormat(contentType) {
+		return fmt.Errorf("route %v unsupported default format %v", r.URI, r.DefaultFormat)
+	}
+
+	r._defaultFormat = contentType
+	return nil
+}
+
+//NegotiateFormat returns route output content type that best matches Accept header, quality values (q) are honored
+//and ties are resolved in favour of the route default format
+func (r *Route) NegotiateFormat(accept string) (string, error) {
+	if strings.TrimSpace(accept) == "" {
+		return r._defaultFormat, nil
+	}
+
+	ranges := parseAccept(accept)
+	var result string
+	var resultQuality float64
+	for _, contentType := range r.contentTypes() {
+		if quality := matchQuality(ranges, contentType); quality > resultQuality {
+			result, resultQuality = contentType, quality
+		}
+	}
+
+	if result == "" {
+		return "", NotAcceptableErr(accept)
+	}
+
+	return result, nil
+}
+
+func (r *Route) contentTypes() []string {
+	result := []string{r._defaultFormat}
+	appendIfSupported := func(contentType string) {
+		if contentType != r._defaultFormat && r.supportsFormat(contentType) {
+			result = append(result, contentType)
+		}
+	}
+
+	appendIfSupported(JSONFormat)
+	appendIfSupported(CSVFormat)
+	appendIfSupported(NDJSONFormat)
+	for _, format := range formats.Formats() {
+		appendIfSupported(format.ContentType)
+	}
+
+	return result
+}
+
+func (r *Route) supportsFormat(contentType string) bool {
+	switch contentType {
+	case JSONFormat:
+		return true
+	case CSVFormat:
+		return r.CSV != nil && !r.Stream
+	case NDJSONFormat:
+		return r.Stream
+	}
+
+	if r.Stream {
+		return false
+	}
+
+	_, ok := formats.LookupContentType(contentType)
+	return ok
+}
+
+//matchQuality returns quality of the most specific media range matching content type
+func matchQuality(ranges []*mediaRange, contentType string) float64 {
+	mainType, subType := splitMediaType(contentType)
+	specificity := -1
+	quality := 0.0
+	for _, candidate := range ranges {
+		candidateSpecificity := -1
+		switch {
+		case candidate.mainType == mainType && candidate.subType == subType:
+			candidateSpecificity = 2
+		case candidate.mainType == mainType && candidate.subType == anyMediaType:
+			candidateSpecificity = 1
+		case candidate.mainType == anyMediaType && candidate.subType == anyMediaType:
+			candidateSpecificity = 0
+		}
+
+		if candidateSpecificity > specificity {
+			specificity, quality = candidateSpecificity, candidate.quality
+		}
+	}
+
+	return quality
+}
+
+func parseAccept(accept string) []*mediaRange {
+	var result []*mediaRange
+	for _, item := range strings.Split(accept, ",") {
+		segments := strings.Split(item, ";")
+		mainType, subType := splitMediaType(strings.TrimSpace(segments[0]))
+		if mainType == "" {
+			continue
+		}
+
+		aRange := &mediaRange{mainType: mainType, subType: subType, quality: 1}
+		for _, param := range segments[1:] {
+			name, value, ok := cutParam(param)
+			if !ok || name != "q" {
+				continue
+			}
+
+			if quality, err := strconv.ParseFloat(value, 64); err == nil && quality >= 0 && quality <= 1 {
+				aRange.quality = quality
+			}
+		}
+
+		result = append(result, aRange)
+	}
+
+	return result
+}
+
+func splitMediaType(mediaType string) (string, string) {
+	mediaType = strings.ToLower(mediaType)
+	if mediaType == anyMediaType {
+		return anyMediaType, anyMediaType
+	}
+
+	index := strings.Index(mediaType, "/")
+	if index == -1 {
+		return "", ""
+	}
+
+	return mediaType[:index], mediaType[index+1:]
+}
+
+func cutParam(param string) (string, string, bool) {
+	index := strings.Index(param, "=")
+	if index == -1 {
+		return "", "", false
+	}
+
+	return strings.ToLower(strings.TrimSpace(param[:index])), strings.TrimSpace(param[index+1:]), true
+}
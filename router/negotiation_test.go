@@ -0,0 +1,87 @@
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoute_NegotiateFormat(t *testing.T) {
+	testcases := []struct {
+		description string
+		route       *Route
+		accept      string
+		expect      string
+		expectErr   bool
+	}{
+		{
+			description: "no accept header",
+			route:       &Route{},
+			expect:      JSONFormat,
+		},
+		{
+			description: "no accept header, route default",
+			route:       &Route{Output: Output{DefaultFormat: "csv", CSV: &CSVConfig{}}},
+			expect:      CSVFormat,
+		},
+		{
+			description: "exact match",
+			route:       &Route{Output: Output{CSV: &CSVConfig{}}},
+			accept:      "text/csv",
+			expect:      CSVFormat,
+		},
+		{
+			description: "quality values",
+			route:       &Route{Output: Output{CSV: &CSVConfig{}}},
+			accept:      "application/json;q=0.5, text/csv;q=0.8, */*;q=0.1",
+			expect:      CSVFormat,
+		},
+		{
+			description: "wildcard falls back to route default",
+			route:       &Route{Output: Output{DefaultFormat: "yaml"}},
+			accept:      "*/*",
+			expect:      YAMLFormat,
+		},
+		{
+			description: "type wildcard",
+			route:       &Route{},
+			accept:      "text/html, application/*;q=0.9",
+			expect:      JSONFormat,
+		},
+		{
+			description: "csv without route CSV config",
+			route:       &Route{},
+			accept:      "text/csv",
+			expectErr:   true,
+		},
+		{
+			description: "explicitly excluded",
+			route:       &Route{},
+			accept:      "application/json;q=0, application/*;q=0",
+			expectErr:   true,
+		},
+		{
+			description: "stream route",
+			route:       &Route{Output: Output{Stream: true}},
+			accept:      "application/x-ndjson, application/json;q=0.5",
+			expect:      NDJSONFormat,
+		},
+	}
+
+	for _, testcase := range testcases {
+		if !assert.Nil(t, testcase.route.initDefaultFormat(), testcase.description) {
+			continue
+		}
+
+		actual, err := testcase.route.NegotiateFormat(testcase.accept)
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			continue
+		}
+
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, actual, testcase.description)
+	}
+}
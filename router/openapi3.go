@@ -350,6 +350,16 @@ func (g *generator) generateOperation(route *Route, method string) (*openapi3.Op
 		return nil, err
 	}
 
+	if lock := route.View.OptimisticLock(); lock != nil && route.Service == ExecutorServiceType {
+		parameters = append(parameters, g.ifMatchParam(lock))
+		if responses != nil {
+			responses["409"] = &openapi3.Response{
+				Description: stringPtr(fmt.Sprintf("Conflict response, record was modified or %v value is outdated", lock.Column)),
+				Content:     responses["Default"].Content,
+			}
+		}
+	}
+
 	operation := &openapi3.Operation{
 		Extension:   nil,
 		Tags:        nil,
@@ -364,6 +374,15 @@ func (g *generator) generateOperation(route *Route, method string) (*openapi3.Op
 	return operation, nil
 }
 
+func (g *generator) ifMatchParam(lock *view.OptimisticLock) *openapi3.Parameter {
+	return &openapi3.Parameter{
+		Name:        HeaderIfMatch,
+		In:          string(view.HeaderKind),
+		Description: fmt.Sprintf("Expected %v value, overrides %v value from the request body, update fails with 409 status if record was modified", lock.Column, lock.Column),
+		Schema:      &openapi3.Schema{Type: stringOutput},
+	}
+}
+
 func (g *generator) viewParameters(aView *view.View, route *Route) ([]*openapi3.Parameter, error) {
 	parameters := make([]*openapi3.Parameter, 0)
 	for _, param := range aView.Template.Parameters {
@@ -402,6 +421,18 @@ func (g *generator) viewParameters(aView *view.View, route *Route) ([]*openapi3.
 		return nil, err
 	}
 
+	if err := g.appendBuiltInParam(&parameters, route, aView.Selector.CursorParam); err != nil {
+		return nil, err
+	}
+
+	if err := g.appendBuiltInParam(&parameters, route, aView.Selector.GroupByParam); err != nil {
+		return nil, err
+	}
+
+	if err := g.appendBuiltInParam(&parameters, route, aView.Selector.AggParam); err != nil {
+		return nil, err
+	}
+
 	return parameters, nil
 }
 
@@ -531,14 +562,22 @@ func (g *generator) responses(route *Route, method string) (openapi3.Responses,
 		return nil, err
 	}
 
+	successContent := map[string]*openapi3.MediaType{
+		applicationJson: {
+			Schema: successSchema,
+		},
+	}
+
+	if route.Service == ReaderServiceType {
+		for contentType := range route._formats {
+			successContent[contentType] = &openapi3.MediaType{Schema: successSchema}
+		}
+	}
+
 	responses := openapi3.Responses{}
 	responses["200"] = &openapi3.Response{
 		Description: stringPtr("Success response"),
-		Content: map[string]*openapi3.MediaType{
-			applicationJson: {
-				Schema: successSchema,
-			},
-		},
+		Content:     successContent,
 	}
 
 	errorSchema, err := g.getOrGenerateSchema(route, errorType, false, errorSchemaDescription, "")
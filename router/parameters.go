@@ -66,6 +66,31 @@ func (p *RequestParams) init(request *http.Request, route *Route) (string, error
 	return p.initRequestBody(request, route)
 }
 
+//clone returns RequestParams copy bound to the request, used by the background cache refresh
+func (p *RequestParams) clone(request *http.Request) *RequestParams {
+	result := *p
+	result.request = request
+	result.queryIndex = make(url.Values, len(p.queryIndex))
+	for key, values := range p.queryIndex {
+		result.queryIndex[key] = append([]string(nil), values...)
+	}
+
+	result.pathIndex = make(map[string]string, len(p.pathIndex))
+	for key, value := range p.pathIndex {
+		result.pathIndex[key] = value
+	}
+
+	result.cookies = make([]*http.Cookie, 0, len(p.cookies))
+	result.cookiesIndex = make(map[string]*http.Cookie, len(p.cookies))
+	for _, cookie := range p.cookies {
+		aCookie := *cookie
+		result.cookies = append(result.cookies, &aCookie)
+		result.cookiesIndex[aCookie.Name] = &aCookie
+	}
+
+	return &result
+}
+
 func (p *RequestParams) queryParam(name string, defaultValue string) string {
 	values, ok := p.queryIndex[name]
 	if !ok {
@@ -144,15 +169,35 @@ func (p *RequestParams) parseRequestBody(body []byte, route *Route) error {
 }
 
 func (p *RequestParams) outputFormat() string {
-	requestedFormat := strings.ToLower(p.queryParam(FormatQuery, ""))
-	switch requestedFormat {
-	case CSVQueryFormat:
-		return CSVFormat
+	if contentType, ok := formatContentType(p.queryParam(FormatQuery, "")); ok {
+		return contentType
 	}
 
 	return JSONFormat
 }
 
+func (p *RequestParams) hasFormatQuery() bool {
+	return p.queryParam(FormatQuery, "") != ""
+}
+
+//formatContentType returns content type for given _format query param value
+func formatContentType(queryFormat string) (string, bool) {
+	switch strings.ToLower(queryFormat) {
+	case JSONQueryFormat:
+		return JSONFormat, true
+	case CSVQueryFormat:
+		return CSVFormat, true
+	case NDJSONQueryFormat:
+		return NDJSONFormat, true
+	}
+
+	if format, ok := LookupFormat(queryFormat); ok {
+		return format.ContentType, true
+	}
+
+	return "", false
+}
+
 func (p *RequestParams) unmarshaller(route *Route) (*Marshaller, error) {
 	switch p.InputFormat {
 	case CSVFormat:
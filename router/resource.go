@@ -43,6 +43,7 @@ type (
 		Info             openapi3.Info
 		ColumnsDiscovery bool
 		EnableDebug      *bool
+		DryRun           *DryRun
 		_visitors        view.Visitors
 		cfs              afs.Service
 		Resource         *view.Resource
@@ -129,6 +130,10 @@ func (r *Resource) Init(ctx context.Context) error {
 			route.RevealMetric = r.RevealMetric
 		}
 
+		if route.DryRun == nil {
+			route.DryRun = r.DryRun
+		}
+
 		aBool := true
 		route.EnableDebug = &aBool
 	}
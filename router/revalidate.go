@@ -0,0 +1,57 @@
+package router
+
+import (
+	"context"
+	"github.com/viant/datly/router/cache"
+	"net/http"
+)
+
+//discardResponse is used by the background cache refresh, the response is only stored in the cache
+type discardResponse struct {
+	header http.Header
+}
+
+func (d *discardResponse) Header() http.Header {
+	return d.header
+}
+
+func (d *discardResponse) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (d *discardResponse) WriteHeader(int) {}
+
+//revalidateCache reads stale entry again in the background, concurrent requests are served the stale entry meanwhile
+func (r *Router) revalidateCache(session *ReaderSession, entry *cache.Entry) {
+	refreshSession := session.detach(&discardResponse{header: http.Header{}})
+	session.Route.Cache.Revalidate(entry, func(fresh *cache.Entry) {
+		defer r.releaseCache(refreshSession, fresh)
+		if _, err := r.readAndWriteResponse(context.Background(), refreshSession, fresh); err != nil {
+			refreshSession.Route.View.Logger.Log("failed to revalidate %v cache entry: %v\n", refreshSession.Route.URI, err)
+		}
+	})
+}
+
+//detach returns session copy writing to the response, selectors, parameters and request are copied,
+//so that the background cache refresh does not share them with the request handler
+func (s *ReaderSession) detach(response http.ResponseWriter) *ReaderSession {
+	request := s.Request.Clone(context.Background())
+	return &ReaderSession{
+		RequestParams: s.RequestParams.clone(request),
+		Route:         s.Route,
+		Request:       request,
+		Response:      response,
+		Selectors:     s.Selectors.Clone(),
+	}
+}
+
+//releaseCache releases entry flight, it is deferred by the handler owning the entry, so that requests waiting for
+//the entry do not wait for it if it was not stored, i.e. on error, early return or panic. Entries passed to Put
+//are released by Put, once stored
+func (r *Router) releaseCache(session *ReaderSession, entry *cache.Entry) {
+	if session.Route.Cache == nil || session.cacheStored {
+		return
+	}
+
+	session.Route.Cache.Release(entry)
+}
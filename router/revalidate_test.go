@@ -0,0 +1,47 @@
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReaderSession_Detach(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/api/events?ev_fields=Id", nil)
+	request.Header.Set(HeaderAccept, "application/json")
+	request.AddCookie(&http.Cookie{Name: "token", Value: "abc"})
+	params := &RequestParams{request: request, queryIndex: request.URL.Query(), pathIndex: map[string]string{"id": "1"}, cookies: request.Cookies()}
+	params.cookiesIndex = map[string]*http.Cookie{"token": params.cookies[0]}
+
+	selector := view.NewSelector()
+	selector.Columns = []string{"id"}
+	selector.Cursor = []interface{}{1}
+	session := &ReaderSession{
+		RequestParams: params,
+		Request:       request,
+		Selectors:     &view.Selectors{Index: map[string]*view.Selector{"events": selector}},
+	}
+
+	detached := session.detach(httptest.NewRecorder())
+	detached.Request.Header.Set(HeaderAccept, "text/csv")
+	detached.RequestParams.queryIndex.Set("ev_fields", "Name")
+	detached.RequestParams.pathIndex["id"] = "2"
+	detached.RequestParams.cookiesIndex["token"].Value = "xyz"
+	detachedSelector := detached.Selectors.Index["events"]
+	detachedSelector.Columns[0] = "name"
+	detachedSelector.Cursor[0] = 2
+	detachedSelector.Add("Quantity", false)
+	detachedSelector.SetTotalCount(10)
+
+	assert.True(t, detached.RequestParams.request == detached.Request)
+	assert.Equal(t, "application/json", session.Request.Header.Get(HeaderAccept))
+	assert.Equal(t, "Id", session.RequestParams.queryParam("ev_fields", ""))
+	assert.Equal(t, "1", session.RequestParams.pathVariable("id", ""))
+	assert.Equal(t, "abc", session.RequestParams.cookie("token"))
+	assert.Equal(t, []string{"id"}, selector.Columns)
+	assert.Equal(t, []interface{}{1}, selector.Cursor)
+	assert.False(t, selector.Has("quantity"))
+	assert.Equal(t, 0, selector.CurrentTotalCount())
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	"fmt"
+	"github.com/viant/datly/executor"
 	"github.com/viant/datly/reader"
 	"github.com/viant/datly/router/cache"
 	"github.com/viant/datly/router/marshal"
@@ -14,6 +15,7 @@ import (
 	"github.com/viant/xunsafe"
 	"net/http"
 	"reflect"
+	"strings"
 )
 
 type Style string
@@ -28,12 +30,19 @@ const (
 	ReaderServiceType   ServiceType = "Reader"
 	ExecutorServiceType ServiceType = "Executor"
 
-	CSVQueryFormat = "csv"
-	CSVFormat      = "text/csv"
-	JSONFormat     = "application/json"
-	FormatQuery    = "_format"
+	JSONQueryFormat   = "json"
+	CSVQueryFormat    = "csv"
+	CSVFormat         = "text/csv"
+	JSONFormat        = "application/json"
+	NDJSONQueryFormat = "ndjson"
+	NDJSONFormat      = "application/x-ndjson"
+	FormatQuery       = "_format"
+
+	defaultStreamBatchSize = 1000
 
 	HeaderContentType = "Content-Type"
+	HeaderAccept      = "Accept"
+	HeaderVary        = "Vary"
 )
 
 type (
@@ -48,6 +57,10 @@ type (
 		Cors        *Cors
 		EnableAudit bool
 		EnableDebug *bool
+		ExecMode    executor.Mode   `json:",omitempty"` //executor statements mode: sequential (default) or parallel
+		TxOptions   *view.TxOptions `json:",omitempty"` //executor transaction options, overrides View TxOptions
+		DryRun      *DryRun         `json:",omitempty"` //enables dry-run requests
+		BatchSize   int             `json:",omitempty"` //executor $sqlx.Insert batch size, defaults to 1000
 		Output
 		Index
 
@@ -80,10 +93,16 @@ type (
 		ReturnBody        bool `json:",omitempty"`
 		RequestBodySchema *view.Schema
 		ResponseBody      *BodySelector
+		Stream            bool   `json:",omitempty"` //writes records incrementally as JSON array or new line delimited JSON
+		StreamBatchSize   int    `json:",omitempty"` //number of records read (with relations) at a time, defaults to 1000
+		DefaultFormat     string `json:",omitempty"` //output format used when neither _format nor Accept header selects one, defaults to json
 
 		_caser            *format.Case
 		_excluded         map[string]bool
 		_outputMarshaller *json.Marshaller
+		_streamMarshaller *json.Marshaller
+		_formats          map[string]FormatMarshaller
+		_defaultFormat    string
 		_responseSetter   *responseSetter
 	}
 
@@ -102,10 +121,18 @@ type (
 		bodyField   *xunsafe.Field
 		metaField   *xunsafe.Field
 		infoField   *xunsafe.Field
+		cursorField *xunsafe.Field
+		totalField  *xunsafe.Field
+		resultField *xunsafe.Field
 		debug       *xunsafe.Field
 		rType       reflect.Type
 	}
 
+	responsePage struct {
+		nextCursor string
+		total      int
+	}
+
 	ResponseStatus struct {
 		Status  string      `json:",omitempty"`
 		Message interface{} `json:",omitempty"`
@@ -138,6 +165,23 @@ func (r *Route) Init(ctx context.Context, resource *Resource) error {
 	if err := r.initCardinality(); err != nil {
 		return err
 	}
+
+	if err := r.ExecMode.Validate(); err != nil {
+		return fmt.Errorf("invalid route %v %v: %w", r.Method, r.URI, err)
+	}
+
+	if r.TxOptions != nil {
+		if err := r.TxOptions.Init(); err != nil {
+			return fmt.Errorf("invalid route %v %v: %w", r.Method, r.URI, err)
+		}
+	}
+
+	if r.DryRun != nil {
+		if err := r.DryRun.Init(); err != nil {
+			return fmt.Errorf("invalid route %v %v: %w", r.Method, r.URI, err)
+		}
+	}
+
 	r.View.Standalone = true
 	if r.View.Name == "" {
 		r.View.Name = r.View.Ref
@@ -181,6 +225,14 @@ func (r *Route) Init(ctx context.Context, resource *Resource) error {
 		return err
 	}
 
+	if err := r.initFormatMarshallers(); err != nil {
+		return err
+	}
+
+	if err := r.initStreamIfNeeded(); err != nil {
+		return err
+	}
+
 	if err := r.initServiceType(); err != nil {
 		return err
 	}
@@ -197,6 +249,10 @@ func (r *Route) Init(ctx context.Context, resource *Resource) error {
 		return err
 	}
 
+	if err := r.initDefaultFormat(); err != nil {
+		return err
+	}
+
 	r.initDebugStyleIfNeeded()
 	return nil
 }
@@ -283,6 +339,57 @@ func (r *Route) initMarshaller() error {
 	return nil
 }
 
+func (r *Route) initFormatMarshallers() error {
+	r._formats = map[string]FormatMarshaller{}
+	for _, format := range formats.Formats() {
+		marshaller, err := format.NewMarshaller(r.responseType(), r.jsonConfig())
+		if err != nil {
+			return fmt.Errorf("failed to create route %v %v marshaller: %w", r.URI, format.Name, err)
+		}
+
+		r._formats[format.ContentType] = marshaller
+	}
+
+	return nil
+}
+
+//FormatMarshaller returns route response marshaller for given content type
+func (r *Route) FormatMarshaller(contentType string) (FormatMarshaller, bool) {
+	marshaller, ok := r._formats[contentType]
+	return marshaller, ok
+}
+
+func (r *Route) initStreamIfNeeded() error {
+	if !r.Stream {
+		return nil
+	}
+
+	if r.Cardinality != view.Many {
+		return fmt.Errorf("route %v stream requires %v cardinality", r.URI, view.Many)
+	}
+
+	if r.StreamBatchSize == 0 {
+		r.StreamBatchSize = defaultStreamBatchSize
+	}
+
+	config := r.jsonConfig()
+	if r.ResponseField != "" {
+		exclude := make([]string, 0, len(r.Exclude))
+		for _, excluded := range r.Exclude {
+			exclude = append(exclude, strings.TrimPrefix(excluded, r.ResponseField+"."))
+		}
+		config.Exclude = marshal.Exclude(exclude).Index()
+	}
+
+	marshaller, err := json.New(r.View.Schema.Type(), config)
+	if err != nil {
+		return err
+	}
+
+	r._streamMarshaller = marshaller
+	return nil
+}
+
 func (r *Route) jsonConfig() marshal.Default {
 	return marshal.Default{
 		OmitEmpty:  r.OmitEmpty,
@@ -302,7 +409,16 @@ func (r *Route) initCors(resource *Resource) {
 }
 
 func (r *Route) initStyle() error {
+	var resultFieldName string
+	if r.ResponseBody != nil {
+		resultFieldName = r.ResponseBody.ResultField
+	}
+
 	if (r.Style == "" || r.Style == BasicStyle) && r.ResponseField == "" {
+		if resultFieldName != "" {
+			return fmt.Errorf("invalid route %v %v, ResponseBody ResultField requires %v style", r.Method, r.URI, ComprehensiveStyle)
+		}
+
 		r.Style = BasicStyle
 		return nil
 	}
@@ -350,12 +466,43 @@ func (r *Route) initStyle() error {
 		})
 	}
 
+	var cursorFieldName string
+	if r.Cardinality == view.Many && r.View.CanUseSelectorCursor() {
+		cursorFieldName = "NextCursor"
+		responseFields = append(responseFields, reflect.StructField{
+			Name: cursorFieldName,
+			Tag:  `json:",omitempty"`,
+			Type: reflect.TypeOf(""),
+		})
+	}
+
+	var totalFieldName string
+	if r.Cardinality == view.Many && r.View.IsCountEnabled() {
+		totalFieldName = "Total"
+		responseFields = append(responseFields, reflect.StructField{
+			Name: totalFieldName,
+			Type: reflect.TypeOf(0),
+		})
+	}
+
+	if resultFieldName != "" {
+		responseFields = append(responseFields, reflect.StructField{
+			Name:    resultFieldName,
+			Tag:     `json:",omitempty"`,
+			Type:    reflect.TypeOf(&executor.Result{}),
+			PkgPath: r.PgkPath(resultFieldName),
+		})
+	}
+
 	responseType := reflect.StructOf(responseFields)
 	r._responseSetter = &responseSetter{
 		statusField: FieldByName(responseType, "ResponseStatus"),
 		bodyField:   FieldByName(responseType, r.ResponseField),
 		metaField:   FieldByName(responseType, metaFieldName),
 		infoField:   FieldByName(responseType, "DatlyDebug"),
+		cursorField: FieldByName(responseType, cursorFieldName),
+		totalField:  FieldByName(responseType, totalFieldName),
+		resultField: FieldByName(responseType, resultFieldName),
 		rType:       responseType,
 	}
 
@@ -504,7 +651,12 @@ func (r *Route) initCache(ctx context.Context) error {
 		return nil
 	}
 
-	return r.Cache.Init(ctx)
+	if err := r.Cache.Init(ctx); err != nil {
+		return err
+	}
+
+	view.RegisterInvalidator("route|"+r.Cache.Location, r.Cache, r.View.RelatedTags()...)
+	return nil
 }
 
 func (r *Route) initCompression(resource *Resource) {
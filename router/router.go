@@ -45,6 +45,8 @@ const (
 
 	DatlyServiceTimeHeader = "Datly-Service-Time"
 	DatlyServiceInitHeader = "Datly-Service-Init"
+
+	TotalCountHeader = "X-Total-Count"
 )
 
 var errorFilters = json.NewFilters(&json.FilterEntry{
@@ -72,6 +74,8 @@ type (
 		Request       *http.Request
 		Response      http.ResponseWriter
 		Selectors     *view.Selectors
+
+		cacheStored bool //cache entry was passed to Put, which releases it
 	}
 )
 
@@ -286,12 +290,32 @@ func (r *Router) viewHandler(route *Route) viewHandler {
 			return
 		}
 
+		dryRun, err := route.dryRunMode(request)
+		if err != nil {
+			r.writeErr(session.Response, session.Route, err, http.StatusForbidden)
+			return
+		}
+
+		if dryRun != "" {
+			r.writeReaderDryRun(ctx, session, dryRun)
+			return
+		}
+
+		if route.Stream && !session.Selectors.Lookup(route.View).IsAggregation() {
+			r.streamResponseWithErrorHandler(ctx, session)
+			return
+		}
+
 		cacheEntry, err := r.cacheEntry(ctx, session)
 		if err != nil {
 			r.writeErr(session.Response, session.Route, err, http.StatusInternalServerError)
 		}
 
 		if cacheEntry != nil && cacheEntry.Has() {
+			if cacheEntry.Stale() {
+				r.revalidateCache(session, cacheEntry)
+			}
+
 			r.writeResponse(ctx, session, cacheEntry)
 			return
 		}
@@ -306,6 +330,12 @@ func (r *Router) buildSession(ctx context.Context, response http.ResponseWriter,
 		return nil, http.StatusBadRequest, err
 	}
 
+	if !requestParams.hasFormatQuery() {
+		if requestParams.OutputFormat, err = route.NegotiateFormat(requestParams.header(HeaderAccept)); err != nil {
+			return nil, http.StatusNotAcceptable, err
+		}
+	}
+
 	if route.CSV == nil && requestParams.OutputFormat == CSVFormat {
 		return nil, http.StatusBadRequest, UnsupportedFormatErr(CSVFormat)
 	}
@@ -329,6 +359,7 @@ func UnsupportedFormatErr(format string) error {
 }
 
 func (r *Router) writeResponseWithErrorHandler(ctx context.Context, session *ReaderSession, cacheEntry *cache.Entry) {
+	defer r.releaseCache(session, cacheEntry)
 	httpCode, err := r.readAndWriteResponse(ctx, session, cacheEntry)
 	if err != nil {
 		r.writeErr(session.Response, session.Route, err, httpCode)
@@ -336,6 +367,10 @@ func (r *Router) writeResponseWithErrorHandler(ctx context.Context, session *Rea
 }
 
 func (r *Router) readAndWriteResponse(ctx context.Context, session *ReaderSession, entry *cache.Entry) (statusCode int, err error) {
+	if session.Selectors.Lookup(session.Route.View).IsAggregation() {
+		return r.aggregateAndWriteResponse(ctx, session, entry)
+	}
+
 	rValue, viewMeta, readerStats, err := r.readValue(session)
 
 	if err != nil {
@@ -356,6 +391,8 @@ func (r *Router) readAndWriteResponse(ctx context.Context, session *ReaderSessio
 		return http.StatusInternalServerError, err
 	}
 
+	r.addValidators(session, payloadReader, resultMarshalled, unsafe.Pointer(rValue.Pointer()))
+
 	templateMeta := session.Route.View.Template.Meta
 	if templateMeta != nil && templateMeta.Kind == view.MetaTypeHeader && viewMeta != nil {
 		data, err := goJson.Marshal(viewMeta)
@@ -366,6 +403,11 @@ func (r *Router) readAndWriteResponse(ctx context.Context, session *ReaderSessio
 		payloadReader.AddHeader(templateMeta.Name, string(data))
 	}
 
+	if session.Route.View.IsCountEnabled() {
+		totalCount := session.Selectors.Lookup(session.Route.View).CurrentTotalCount()
+		payloadReader.AddHeader(TotalCountHeader, strconv.Itoa(totalCount))
+	}
+
 	for _, stat := range readerStats {
 		marshal, err := goJson.Marshal(stat)
 		if err != nil {
@@ -375,7 +417,7 @@ func (r *Router) readAndWriteResponse(ctx context.Context, session *ReaderSessio
 	}
 
 	if entry != nil {
-		r.updateCache(ctx, session.Route, entry, payloadReader)
+		r.updateCache(ctx, session, entry, payloadReader)
 	}
 
 	r.writeResponse(ctx, session, payloadReader)
@@ -407,13 +449,16 @@ func (r *Router) readValue(readerSession *ReaderSession) (reflect.Value, interfa
 	return destValue, session.ViewMeta, readerStats, nil
 }
 
-func (r *Router) updateCache(ctx context.Context, route *Route, cacheEntry *cache.Entry, response *RequestDataReader) {
+//updateCache stores response in the cache, response bytes are taken before the response is written, as writing drains the buffer
+func (r *Router) updateCache(ctx context.Context, session *ReaderSession, cacheEntry *cache.Entry, response *RequestDataReader) {
+	session.cacheStored = true
+	data := response.buffer.Bytes()
 	if !debugEnabled {
-		go r.putCache(ctx, route, cacheEntry, response)
+		go r.putCache(ctx, session.Route, cacheEntry, data, response)
 		return
 	}
 
-	r.putCache(ctx, route, cacheEntry, response)
+	r.putCache(ctx, session.Route, cacheEntry, data, response)
 }
 
 func (r *Router) cacheEntry(ctx context.Context, session *ReaderSession) (*cache.Entry, error) {
@@ -429,8 +474,8 @@ func (r *Router) cacheEntry(ctx context.Context, session *ReaderSession) (*cache
 	return cacheEntry, nil
 }
 
-func (r *Router) putCache(ctx context.Context, route *Route, cacheEntry *cache.Entry, payloadReader *RequestDataReader) {
-	_ = route.Cache.Put(ctx, cacheEntry, payloadReader.buffer.Bytes(), payloadReader.CompressionType(), payloadReader.Headers())
+func (r *Router) putCache(ctx context.Context, route *Route, cacheEntry *cache.Entry, data []byte, payloadReader *RequestDataReader) {
+	_ = route.Cache.Put(ctx, cacheEntry, data, payloadReader.CompressionType(), payloadReader.Headers())
 }
 
 func (r *Router) runBeforeFetch(response http.ResponseWriter, request *http.Request, route *Route) (shouldContinue bool) {
@@ -471,17 +516,19 @@ func (r *Router) marshalResult(session *ReaderSession, destValue reflect.Value,
 		return nil, http.StatusBadRequest, err
 	}
 
-	formatType := session.RequestParams.queryParam(FormatQuery, "")
-	switch strings.ToLower(formatType) {
-	case CSVQueryFormat:
+	if session.RequestParams.OutputFormat == CSVFormat {
 		return r.marshalAsCSV(session, destValue, filters)
 	}
 
+	if marshaller, ok := session.Route.FormatMarshaller(session.RequestParams.OutputFormat); ok {
+		return r.result(session, destValue, marshaller, json.NewFilters(filters...), viewMeta, stats)
+	}
+
 	return r.marshalAsJSON(session, destValue, json.NewFilters(filters...), viewMeta, stats)
 }
 
 func (r *Router) marshalAsJSON(session *ReaderSession, destValue reflect.Value, filters *json.Filters, viewMeta interface{}, stats []*reader.Info) ([]byte, int, error) {
-	payload, httpStatus, err := r.result(session, destValue, filters, viewMeta, stats)
+	payload, httpStatus, err := r.result(session, destValue, session.Route._outputMarshaller, filters, viewMeta, stats)
 	if err != nil {
 		return nil, httpStatus, err
 	}
@@ -493,10 +540,15 @@ func (r *Router) inAWS() bool {
 	return scheme == "s3"
 }
 
-func (r *Router) result(session *ReaderSession, destValue reflect.Value, filters *json.Filters, meta interface{}, stats []*reader.Info) ([]byte, int, error) {
+func (r *Router) result(session *ReaderSession, destValue reflect.Value, marshaller FormatMarshaller, filters *json.Filters, meta interface{}, stats []*reader.Info) ([]byte, int, error) {
 	if session.Route.Cardinality == view.Many {
-		result := r.wrapWithResponseIfNeeded(destValue.Elem().Interface(), session.Route, meta, stats)
-		asBytes, err := session.Route._outputMarshaller.Marshal(result, filters)
+		page, err := r.responsePage(session, destValue)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		result := r.wrapWithResponseIfNeeded(destValue.Elem().Interface(), session.Route, meta, stats, page)
+		asBytes, err := marshaller.Marshal(result, filters)
 		if err != nil {
 			return nil, http.StatusInternalServerError, err
 		}
@@ -511,8 +563,8 @@ func (r *Router) result(session *ReaderSession, destValue reflect.Value, filters
 	case 0:
 		return nil, http.StatusNotFound, nil
 	case 1:
-		result := r.wrapWithResponseIfNeeded(session.Route.View.Schema.Slice().ValueAt(slicePtr, 0), session.Route, meta, stats)
-		asBytes, err := session.Route._outputMarshaller.Marshal(result, filters)
+		result := r.wrapWithResponseIfNeeded(session.Route.View.Schema.Slice().ValueAt(slicePtr, 0), session.Route, meta, stats, nil)
+		asBytes, err := marshaller.Marshal(result, filters)
 		if err != nil {
 			return nil, http.StatusInternalServerError, err
 		}
@@ -599,11 +651,15 @@ func (r *Router) setResponseStatus(route *Route, response reflect.Value, respons
 	}
 }
 
-func (r *Router) wrapWithResponseIfNeeded(response interface{}, route *Route, viewMeta interface{}, stats []*reader.Info) interface{} {
+func (r *Router) wrapWithResponseIfNeeded(response interface{}, route *Route, viewMeta interface{}, stats []*reader.Info, page *responsePage) interface{} {
 	if route._responseSetter == nil {
 		return response
 	}
 
+	return r.newResponse(response, route, viewMeta, stats, page).Elem().Interface()
+}
+
+func (r *Router) newResponse(response interface{}, route *Route, viewMeta interface{}, stats []*reader.Info, page *responsePage) reflect.Value {
 	newResponse := reflect.New(route._responseSetter.rType)
 	responseBodyPtr := unsafe.Pointer(newResponse.Pointer())
 	route._responseSetter.bodyField.SetValue(responseBodyPtr, response)
@@ -611,8 +667,53 @@ func (r *Router) wrapWithResponseIfNeeded(response interface{}, route *Route, vi
 		route._responseSetter.metaField.SetValue(responseBodyPtr, viewMeta)
 	}
 
+	if page != nil {
+		if route._responseSetter.cursorField != nil && page.nextCursor != "" {
+			route._responseSetter.cursorField.SetValue(responseBodyPtr, page.nextCursor)
+		}
+
+		if route._responseSetter.totalField != nil {
+			route._responseSetter.totalField.SetValue(responseBodyPtr, page.total)
+		}
+	}
+
 	r.setResponseStatus(route, newResponse, ResponseStatus{Status: "ok"}, stats)
-	return newResponse.Elem().Interface()
+	return newResponse
+}
+
+func (r *Router) responsePage(session *ReaderSession, destValue reflect.Value) (*responsePage, error) {
+	nextCursor, err := r.nextCursor(session, destValue)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &responsePage{nextCursor: nextCursor}
+	if session.Route.View.IsCountEnabled() {
+		page.total = session.Selectors.Lookup(session.Route.View).CurrentTotalCount()
+	}
+
+	return page, nil
+}
+
+func (r *Router) nextCursor(session *ReaderSession, destValue reflect.Value) (string, error) {
+	aView := session.Route.View
+	if session.Route._responseSetter == nil || session.Route._responseSetter.cursorField == nil {
+		return "", nil
+	}
+
+	selector := session.Selectors.Lookup(aView)
+	limit := selector.Limit
+	if limit == 0 {
+		limit = aView.Selector.Limit
+	}
+
+	slicePtr := unsafe.Pointer(destValue.Pointer())
+	sliceLen := aView.Schema.Slice().Len(slicePtr)
+	if limit == 0 || sliceLen < limit {
+		return "", nil
+	}
+
+	return aView.EncodeCursor(selector, aView.Schema.Slice().ValuePointerAt(slicePtr, sliceLen-1))
 }
 
 func (r *Router) createCacheEntry(ctx context.Context, session *ReaderSession) (*cache.Entry, error) {
@@ -629,6 +730,10 @@ func (r *Router) createCacheEntry(ctx context.Context, session *ReaderSession) (
 		return nil, err
 	}
 
+	if outputFormat := session.RequestParams.OutputFormat; outputFormat != JSONFormat {
+		marshalled = append(marshalled, outputFormat...)
+	}
+
 	return session.Route.Cache.Get(ctx, marshalled, session.Route.View.Name)
 }
 
@@ -696,6 +801,10 @@ func (r *Router) Routes(route string) []*Route {
 func (r *Router) writeResponse(ctx context.Context, session *ReaderSession, payloadReader PayloadReader) {
 	defer payloadReader.Close()
 
+	if r.writeNotModifiedIfNeeded(session, payloadReader) {
+		return
+	}
+
 	redirected, err := r.redirectIfNeeded(ctx, session, payloadReader)
 	if redirected {
 		return
@@ -708,6 +817,7 @@ func (r *Router) writeResponse(ctx context.Context, session *ReaderSession, payl
 
 	session.Response.Header().Add(content.Type, session.RequestParams.OutputFormat)
 	session.Response.Header().Add(content.Type, CharsetUTF8)
+	session.Response.Header().Add(HeaderVary, HeaderAccept)
 	session.Response.Header().Add(ContentLength, strconv.Itoa(payloadReader.Size()))
 	for key, value := range payloadReader.Headers() {
 		session.Response.Header().Add(key, value[0])
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/viant/datly/converter"
 	"github.com/viant/datly/reader"
+	"github.com/viant/datly/router/aggregate"
 	"github.com/viant/datly/router/criteria"
 	"github.com/viant/datly/view"
 	"github.com/viant/toolbox/format"
@@ -206,6 +207,40 @@ func (b *selectorsBuilder) populateSelector(ctx context.Context, selector *view.
 		}
 	}
 
+	if details.View.Selector.CursorParam != nil {
+		if err := b.populateCursor(ctx, selector, details); err != nil {
+			return view.CursorQuery, err
+		}
+	} else {
+		if b.isParamPresent(details, view.CursorQuery) {
+			return view.CursorQuery, fmt.Errorf("can't use cursor on view %v", details.View.Name)
+		}
+	}
+
+	if details.View.Selector.GroupByParam != nil {
+		if err := b.populateGroupBy(ctx, selector, details); err != nil {
+			return view.GroupByQuery, err
+		}
+	} else {
+		if b.isParamPresent(details, view.GroupByQuery) {
+			return view.GroupByQuery, fmt.Errorf("can't use group by on view %v", details.View.Name)
+		}
+	}
+
+	if details.View.Selector.AggParam != nil {
+		if err := b.populateAggregates(ctx, selector, details); err != nil {
+			return view.AggQuery, err
+		}
+	} else {
+		if b.isParamPresent(details, view.AggQuery) {
+			return view.AggQuery, fmt.Errorf("can't use aggregates on view %v", details.View.Name)
+		}
+	}
+
+	if err := b.validateAggregation(selector, details); err != nil {
+		return view.GroupByQuery, err
+	}
+
 	if selector.Limit == 0 && selector.Offset != 0 {
 		return "", fmt.Errorf("can't use offset without limit")
 	}
@@ -232,6 +267,10 @@ func (b *selectorsBuilder) populateCriteria(ctx context.Context, selector *view.
 	}
 
 	sanitizedCriteria, err := criteria.Parse(criteriaExpression, details.View.IndexedColumns(), details.View.Selector.Constraints.SqlMethodsIndexed())
+	if filterErr, ok := err.(*criteria.FilterError); ok {
+		return &JSONError{Object: filterErr}
+	}
+
 	if err != nil {
 		return err
 	}
@@ -710,6 +749,108 @@ func (b *selectorsBuilder) populatePage(ctx context.Context, selector *view.Sele
 	return nil
 }
 
+func (b *selectorsBuilder) populateCursor(ctx context.Context, selector *view.Selector, details *ViewDetails) error {
+	cursorParam := details.View.Selector.CursorParam
+	value, err := b.extractParamValue(ctx, cursorParam, details, selector)
+	if err != nil {
+		return err
+	}
+
+	token, ok := value.(string)
+	if !ok {
+		return typeMismatchError(cursorParam, value)
+	}
+
+	if token == "" {
+		return nil
+	}
+
+	if !details.View.Selector.Constraints.Cursor {
+		return fmt.Errorf("can't use cursor on view %v", details.View.Name)
+	}
+
+	if selector.Offset != 0 {
+		return fmt.Errorf("can't use cursor together with offset or page on view %v", details.View.Name)
+	}
+
+	selector.Cursor, err = details.View.DecodeCursor(selector, token)
+	return err
+}
+
+func (b *selectorsBuilder) populateGroupBy(ctx context.Context, selector *view.Selector, details *ViewDetails) error {
+	groupByParam := details.View.Selector.GroupByParam
+	value, err := b.extractParamValue(ctx, groupByParam, details, selector)
+	if err != nil {
+		return err
+	}
+
+	groupBy, ok := value.(string)
+	if !ok {
+		return typeMismatchError(groupByParam, value)
+	}
+
+	if groupBy == "" {
+		return nil
+	}
+
+	if !details.View.CanUseSelectorAggregation() {
+		return fmt.Errorf("can't use group by on view %v", details.View.Name)
+	}
+
+	selector.GroupBy, err = aggregate.ParseGroupBy(groupBy, details.View.IndexedColumns(), details.View.Selector.Constraints.Aggregation)
+	return err
+}
+
+func (b *selectorsBuilder) populateAggregates(ctx context.Context, selector *view.Selector, details *ViewDetails) error {
+	aggParam := details.View.Selector.AggParam
+	value, err := b.extractParamValue(ctx, aggParam, details, selector)
+	if err != nil {
+		return err
+	}
+
+	aggregates, ok := value.(string)
+	if !ok {
+		return typeMismatchError(aggParam, value)
+	}
+
+	if aggregates == "" {
+		return nil
+	}
+
+	if !details.View.CanUseSelectorAggregation() {
+		return fmt.Errorf("can't use aggregates on view %v", details.View.Name)
+	}
+
+	selector.Aggregates, err = aggregate.ParseAggregates(aggregates, details.View.IndexedColumns(), details.View.Selector.Constraints.Aggregation)
+	return err
+}
+
+func (b *selectorsBuilder) validateAggregation(selector *view.Selector, details *ViewDetails) error {
+	if !selector.IsAggregation() {
+		return nil
+	}
+
+	if len(selector.Cursor) > 0 {
+		return fmt.Errorf("can't use cursor together with aggregation on view %v", details.View.Name)
+	}
+
+	if len(selector.Columns) > 0 {
+		return fmt.Errorf("can't use projection together with aggregation on view %v", details.View.Name)
+	}
+
+	if selector.OrderBy == "" {
+		return nil
+	}
+
+	for _, column := range selector.GroupBy {
+		if column == selector.OrderBy {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("can't order aggregated view %v by %v, only group by columns can be used", details.View.Name, selector.OrderBy)
+}
+
 func canUseColumn(aView *view.View, columnName string) error {
 	_, ok := aView.ColumnByName(columnName)
 	if !ok {
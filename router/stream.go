@@ -0,0 +1,85 @@
+package router
+
+import (
+	"context"
+	"github.com/viant/afs/option/content"
+	"github.com/viant/datly/reader"
+	"github.com/viant/datly/router/marshal/json"
+	"github.com/viant/xunsafe"
+	"net/http"
+)
+
+func (r *Router) streamResponseWithErrorHandler(ctx context.Context, session *ReaderSession) {
+	httpCode, err := r.streamResponse(ctx, session)
+	if err != nil {
+		r.writeErr(session.Response, session.Route, err, httpCode)
+	}
+}
+
+func (r *Router) streamResponse(ctx context.Context, session *ReaderSession) (statusCode int, err error) {
+	if outputFormat := session.RequestParams.OutputFormat; outputFormat != JSONFormat && outputFormat != NDJSONFormat {
+		return http.StatusBadRequest, UnsupportedFormatErr(outputFormat)
+	}
+
+	filters, err := r.buildJsonFilters(session.Route, session.Selectors)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	aView := session.Route.View
+	readerSession := reader.NewSession(nil, aView)
+	readerSession.CacheDisabled = session.IsCacheDisabled()
+	readerSession.IncludeSQL = session.IsMetricDebug()
+	readerSession.Selectors = session.Selectors
+
+	stream := session.Route._streamMarshaller.NewStream(session.Response, json.NewFilters(filters...), session.RequestParams.OutputFormat == NDJSONFormat)
+	slice := aView.Schema.Slice()
+	headerWritten := false
+	err = reader.New().Stream(ctx, readerSession, session.Route.StreamBatchSize, func(batch interface{}) error {
+		if !headerWritten {
+			r.writeStreamHeader(session)
+			headerWritten = true
+		}
+
+		slicePtr := xunsafe.AsPointer(batch)
+		for i := 0; i < slice.Len(slicePtr); i++ {
+			if err := stream.Write(slice.ValueAt(slicePtr, i)); err != nil {
+				return err
+			}
+		}
+
+		if flusher, ok := session.Response.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	if session.Route.EnableAudit {
+		r.logMetrics(session.Route.URI, readerSession.Metrics, readerSession.Stats)
+	}
+
+	if err != nil {
+		if !headerWritten {
+			return http.StatusInternalServerError, err
+		}
+
+		//response was already partially written, the stream is left incomplete so that client can detect the failure
+		aView.Logger.Log("failed to stream %v response: %v\n", session.Route.URI, err)
+		return -1, nil
+	}
+
+	if !headerWritten {
+		r.writeStreamHeader(session)
+	}
+
+	_ = stream.Close()
+	return -1, nil
+}
+
+func (r *Router) writeStreamHeader(session *ReaderSession) {
+	session.Response.Header().Add(content.Type, session.RequestParams.OutputFormat)
+	session.Response.Header().Add(content.Type, CharsetUTF8)
+	session.Response.Header().Add(HeaderVary, HeaderAccept)
+	session.Response.WriteHeader(http.StatusOK)
+}
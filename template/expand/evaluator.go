@@ -10,9 +10,13 @@ import (
 )
 
 const (
-	Criteria    = "criteria"
-	Logger      = "logger"
-	HttpService = "http"
+	Criteria      = "criteria"
+	Logger        = "logger"
+	HttpService   = "http"
+	Transaction   = "tx"
+	UpsertService = "upsert"
+	SqlxService   = "sqlx"
+	OutboxService = "outbox"
 )
 
 type (
@@ -76,6 +80,22 @@ func NewEvaluator(consts []ConstUpdater, paramSchema, presenceSchema reflect.Typ
 		return nil, err
 	}
 
+	if err = evaluator.planner.DefineVariable(Transaction, reflect.TypeOf(&Tx{})); err != nil {
+		return nil, err
+	}
+
+	if err = evaluator.planner.DefineVariable(UpsertService, reflect.TypeOf(&Upsert{})); err != nil {
+		return nil, err
+	}
+
+	if err = evaluator.planner.DefineVariable(SqlxService, reflect.TypeOf(&Sqlx{})); err != nil {
+		return nil, err
+	}
+
+	if err = evaluator.planner.DefineVariable(OutboxService, reflect.TypeOf(&Outbox{})); err != nil {
+		return nil, err
+	}
+
 	if err = evaluator.planner.RegisterFunctionKind(queryFunctionName, queryFnHandler); err != nil {
 		return nil, err
 	}
@@ -141,6 +161,22 @@ func (e *Evaluator) Evaluate(externalParams, presenceMap interface{}, viewParam
 		return nil, nil, err
 	}
 
+	if err := newState.SetValue(Transaction, &Tx{}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := newState.SetValue(UpsertService, &Upsert{criteria: viewParam.sanitizer, dialect: viewParam.Dialect}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := newState.SetValue(SqlxService, &Sqlx{criteria: viewParam.sanitizer}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := newState.SetValue(OutboxService, &Outbox{criteria: viewParam.sanitizer}); err != nil {
+		return nil, nil, err
+	}
+
 	if err := e.executor.Exec(newState); err != nil {
 		return nil, nil, err
 	}
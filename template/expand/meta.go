@@ -2,6 +2,8 @@ package expand
 
 import (
 	"database/sql"
+	"github.com/viant/datly/view/dialect"
+	"github.com/viant/datly/view/sequence"
 	"strings"
 )
 
@@ -19,12 +21,19 @@ type (
 		TableName() string
 		ResultLimit() int
 		Db() (*sql.DB, error)
+		Dialect() *dialect.Dialect
+	}
+
+	//SequenceSource returns tables ID allocation strategies used by the sequencer
+	SequenceSource interface {
+		SequenceConfigs() []*sequence.Config
 	}
 
 	MetaExtras interface {
 		CurrentLimit() int
 		CurrentOffset() int
 		CurrentPage() int
+		CurrentTotalCount() int
 	}
 
 	MetaBatch interface {
@@ -41,9 +50,11 @@ type (
 		Limit        int
 		Offset       int
 		Page         int
+		TotalCount   int
 		Args         []interface{}
 		NonWindowSQL string
 		ParentValues []interface{}
+		Dialect      *dialect.Dialect
 	}
 
 	MockExpander struct{}
@@ -83,7 +94,7 @@ func (m *MetaParam) ColIn(prefix, column string) (string, error) {
 
 	bindings := m.addBindings(m.ParentValues)
 	if bindings == "" {
-		return prefix + " 1 = 0 ", nil
+		return prefix + " " + m.falsePredicate() + " ", nil
 	}
 
 	if prefix != "" && !strings.HasSuffix(prefix, " ") {
@@ -93,6 +104,14 @@ func (m *MetaParam) ColIn(prefix, column string) (string, error) {
 	return prefix + column + " IN (" + bindings + " )", nil
 }
 
+func (m *MetaParam) falsePredicate() string {
+	if m.Dialect == nil {
+		return dialect.ANSI.Predicate(false)
+	}
+
+	return m.Dialect.Predicate(false)
+}
+
 func (m *MetaParam) addBindings(args []interface{}) string {
 	_, bindings := AsBindings("", args)
 	m.sanitizer.addAll(args...)
@@ -156,11 +175,13 @@ func NewMetaParam(metaSource MetaSource, aSelector MetaExtras, batchData MetaBat
 	limit := metaSource.ResultLimit()
 	offset := 0
 	page := 0
+	totalCount := 0
 
 	if aSelector != nil {
 		limit = NotZeroOf(aSelector.CurrentLimit(), limit)
 		offset = NotZeroOf(aSelector.CurrentOffset(), offset)
 		page = NotZeroOf(aSelector.CurrentPage(), 0)
+		totalCount = aSelector.CurrentTotalCount()
 	}
 
 	var args []interface{}
@@ -178,12 +199,14 @@ func NewMetaParam(metaSource MetaSource, aSelector MetaExtras, batchData MetaBat
 		Limit:        limit,
 		Page:         page,
 		Offset:       offset,
+		TotalCount:   totalCount,
 		Args:         args,
 		NonWindowSQL: SQLExec,
 		sanitizer: &SQLCriteria{
 			MetaSource: metaSource,
 		},
 		ParentValues: colInArgs,
+		Dialect:      metaSource.Dialect(),
 	}
 
 	return viewParam
@@ -202,5 +225,6 @@ func NotZeroOf(values ...int) int {
 func MockMetaParam() *MetaParam {
 	return &MetaParam{
 		sanitizer: &SQLCriteria{},
+		Dialect:   dialect.ANSI,
 	}
 }
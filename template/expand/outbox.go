@@ -0,0 +1,45 @@
+package expand
+
+import (
+	"fmt"
+	"github.com/viant/datly/view/outbox"
+)
+
+type (
+	//OutboxSource returns events outbox configuration
+	OutboxSource interface {
+		OutboxConfig() *outbox.Config
+	}
+
+	//Outbox exposes events outbox to the executor template
+	Outbox struct {
+		criteria *SQLCriteria
+	}
+)
+
+//Publish returns statement writing event into the outbox table, event is written within executor transaction
+//and delivered with outbox publisher after commit
+func (o *Outbox) Publish(topic string, payload interface{}) (string, error) {
+	config := o.config()
+	if config == nil {
+		return "", fmt.Errorf("outbox was not configured, unable to publish %v event", topic)
+	}
+
+	event, err := outbox.NewEvent(topic, payload)
+	if err != nil {
+		return "", err
+	}
+
+	SQL, args := event.InsertSQL(config.Table)
+	o.criteria.ParamsGroup = append(o.criteria.ParamsGroup, args...)
+	return SQL + ";", nil
+}
+
+func (o *Outbox) config() *outbox.Config {
+	source, ok := o.criteria.MetaSource.(OutboxSource)
+	if !ok {
+		return nil
+	}
+
+	return source.OutboxConfig()
+}
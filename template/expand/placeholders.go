@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/viant/datly/executor/sequencer"
+	"github.com/viant/datly/view/sequence"
 	"github.com/viant/xunsafe"
 	"reflect"
 	"strings"
@@ -24,6 +25,7 @@ type (
 		sliceIndex         map[reflect.Type]*xunsafe.Slice
 		TemplateSQL        string
 		MetaSource         MetaSource
+		Inserts            []*BulkInsert //records inserted in batches by the executor, referenced by BulkInsertStmt index
 	}
 )
 
@@ -35,7 +37,12 @@ func (p *SQLCriteria) Allocate(tableName string, dest interface{}, selector stri
 		return "", fmt.Errorf("error occurred while connecting to DB")
 	}
 
-	service := sequencer.New(context.Background(), db)
+	var configs []*sequence.Config
+	if source, ok := p.MetaSource.(SequenceSource); ok {
+		configs = source.SequenceConfigs()
+	}
+
+	service := sequencer.New(context.Background(), db, configs...)
 	return "", service.Next(tableName, dest, selector)
 }
 
@@ -0,0 +1,45 @@
+package expand
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+//BulkInsertStmt prefixes statement inserting records with sqlx batch insert service, followed by BulkInsert index
+const BulkInsertStmt = "SQLX INSERT "
+
+type (
+	//BulkInsert represents records inserted in batches by the executor
+	BulkInsert struct {
+		Table      string
+		Records    interface{}
+		IDSelector string //records ID path allocated with sequencer before insert, i.e. Id or Items/Id, empty if IDs are not allocated
+	}
+
+	//Sqlx exposes sqlx batch services to the executor template
+	Sqlx struct {
+		criteria *SQLCriteria
+	}
+)
+
+//Insert returns statement inserting records into the table in batches, instead of one INSERT statement per record
+//records IDs matching idSelector are allocated with sequencer, empty idSelector leaves IDs to the database
+func (s *Sqlx) Insert(table string, records interface{}, idSelector string) (string, error) {
+	if table == "" {
+		return "", fmt.Errorf("sqlx insert table was empty")
+	}
+
+	rValue := reflect.ValueOf(records)
+	if !rValue.IsValid() || ((rValue.Kind() == reflect.Ptr || rValue.Kind() == reflect.Slice) && rValue.IsNil()) {
+		return "", nil
+	}
+
+	s.criteria.Inserts = append(s.criteria.Inserts, &BulkInsert{
+		Table:      table,
+		Records:    records,
+		IDSelector: idSelector,
+	})
+
+	return BulkInsertStmt + strconv.Itoa(len(s.criteria.Inserts)-1) + ";", nil
+}
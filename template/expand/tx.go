@@ -0,0 +1,47 @@
+package expand
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var savepointName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+//Tx exposes transaction savepoint helpers to the executor template
+//statements between Savepoint and Release are rolled back to the savepoint, and skipped, if any of them fails
+type Tx struct{}
+
+//Savepoint returns statement creating savepoint with given name
+func (t *Tx) Savepoint(name string) (string, error) {
+	if err := validateSavepoint(name); err != nil {
+		return "", err
+	}
+
+	return "SAVEPOINT " + name + ";", nil
+}
+
+//Release returns statement releasing savepoint with given name
+func (t *Tx) Release(name string) (string, error) {
+	if err := validateSavepoint(name); err != nil {
+		return "", err
+	}
+
+	return "RELEASE SAVEPOINT " + name + ";", nil
+}
+
+//RollbackTo returns statement rolling back transaction to the savepoint with given name
+func (t *Tx) RollbackTo(name string) (string, error) {
+	if err := validateSavepoint(name); err != nil {
+		return "", err
+	}
+
+	return "ROLLBACK TO SAVEPOINT " + name + ";", nil
+}
+
+func validateSavepoint(name string) error {
+	if !savepointName.MatchString(name) {
+		return fmt.Errorf("invalid savepoint name %v", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package expand
+
+import (
+	"fmt"
+	"github.com/viant/datly/view/dialect"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+//Upsert exposes dialect specific insert or update statement to the executor template
+type Upsert struct {
+	criteria *SQLCriteria
+	dialect  *dialect.Dialect
+}
+
+//Record returns statement inserting record into the table, or updating it if record with the same keys already exists
+//record columns are resolved from sqlx tag names, keys is comma separated list of conflict columns
+func (u *Upsert) Record(table string, record interface{}, keys string) (string, error) {
+	if u.dialect == nil {
+		return "", fmt.Errorf("upsert %v is not supported, unknown dialect", table)
+	}
+
+	rValue := reflect.ValueOf(record)
+	for rValue.Kind() == reflect.Ptr || rValue.Kind() == reflect.Interface {
+		if rValue.IsNil() {
+			return "", fmt.Errorf("upsert %v record can't be nil", table)
+		}
+		rValue = rValue.Elem()
+	}
+
+	if rValue.Kind() != reflect.Struct {
+		return "", fmt.Errorf("upsert %v record has to be a struct but was %v", table, rValue.Type().String())
+	}
+
+	var columns, values []string
+	var args []interface{}
+	rType := rValue.Type()
+	for i := 0; i < rType.NumField(); i++ {
+		column, ok := upsertColumn(rType.Field(i))
+		if !ok {
+			continue
+		}
+
+		columns = append(columns, column)
+		values = append(values, "?")
+		args = append(args, rValue.Field(i).Interface())
+	}
+
+	SQL, err := u.dialect.UpsertSQL(table, columns, splitKeys(keys), values)
+	if err != nil {
+		return "", err
+	}
+
+	u.criteria.ParamsGroup = append(u.criteria.ParamsGroup, args...)
+	return SQL, nil
+}
+
+//upsertColumn returns column name of the struct field, false for relations and fields excluded with sqlx:"-" tag
+func upsertColumn(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	sqlxTag := field.Tag.Get("sqlx")
+	if sqlxTag == "-" {
+		return "", false
+	}
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Slice:
+		if fieldType.Elem().Kind() != reflect.Uint8 {
+			return "", false
+		}
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		if fieldType != timeType {
+			return "", false
+		}
+	}
+
+	for _, option := range strings.Split(sqlxTag, ",") {
+		if strings.HasPrefix(option, "name=") {
+			return option[len("name="):], true
+		}
+	}
+
+	return field.Name, true
+}
+
+func splitKeys(keys string) []string {
+	var result []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package expand
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/datly/view/dialect"
+	"testing"
+	"time"
+)
+
+func TestUpsert_Record(t *testing.T) {
+	type eventType struct {
+		Id int
+	}
+
+	type event struct {
+		Id        int       `sqlx:"name=ID"`
+		Name      *string   `sqlx:"name=NAME"`
+		Created   time.Time `sqlx:"name=CREATED"`
+		Payload   []byte
+		Internal  string `sqlx:"-"`
+		EventType *eventType
+		Types     []*eventType
+		counter   int
+	}
+
+	name := "abc"
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	testcases := []struct {
+		description string
+		driver      string
+		record      interface{}
+		keys        string
+		expectSQL   string
+		expectArgs  []interface{}
+		expectErr   bool
+	}{
+		{
+			description: "sqlite on conflict",
+			driver:      "sqlite3",
+			record:      &event{Id: 1, Name: &name, Created: created, Payload: []byte("x"), Internal: "skip", counter: 2},
+			keys:        "ID",
+			expectSQL:   "INSERT INTO EVENTS (ID, NAME, CREATED, Payload) VALUES (?, ?, ?, ?) ON CONFLICT (ID) DO UPDATE SET NAME = excluded.NAME, CREATED = excluded.CREATED, Payload = excluded.Payload",
+			expectArgs:  []interface{}{1, &name, created, []byte("x")},
+		},
+		{
+			description: "mysql on duplicate key",
+			driver:      "mysql",
+			record:      event{Id: 2},
+			keys:        " ID , NAME",
+			expectSQL:   "INSERT INTO EVENTS (ID, NAME, CREATED, Payload) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE CREATED = VALUES(CREATED), Payload = VALUES(Payload)",
+			expectArgs:  []interface{}{2, (*string)(nil), time.Time{}, []byte(nil)},
+		},
+		{
+			description: "unknown driver",
+			driver:      "unknown",
+			record:      &event{Id: 1},
+			keys:        "ID",
+			expectErr:   true,
+		},
+		{
+			description: "nil record",
+			driver:      "sqlite3",
+			record:      (*event)(nil),
+			keys:        "ID",
+			expectErr:   true,
+		},
+		{
+			description: "not a struct",
+			driver:      "sqlite3",
+			record:      map[string]interface{}{"ID": 1},
+			keys:        "ID",
+			expectErr:   true,
+		},
+		{
+			description: "missing keys",
+			driver:      "sqlite3",
+			record:      &event{Id: 1},
+			expectErr:   true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		criteria := &SQLCriteria{}
+		upsert := &Upsert{criteria: criteria, dialect: dialect.Lookup(testcase.driver)}
+		SQL, err := upsert.Record("EVENTS", testcase.record, testcase.keys)
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			assert.Empty(t, criteria.ParamsGroup, testcase.description)
+			continue
+		}
+
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, testcase.expectSQL, SQL, testcase.description)
+		assert.Equal(t, testcase.expectArgs, criteria.ParamsGroup, testcase.description)
+	}
+}
@@ -27,6 +27,10 @@ var builtInMethods = map[string]bool{
 	keywords.ColumnsIn[1:]:        true,
 	keywords.SequencerKey:         true,
 	expand.HttpService:            true,
+	expand.Transaction:            true,
+	expand.UpsertService:          true,
+	expand.SqlxService:            true,
+	expand.OutboxService:          true,
 }
 
 func Sanitize(SQL string, hints map[string]*ParameterHint, consts map[string]interface{}) string {
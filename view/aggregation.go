@@ -0,0 +1,168 @@
+package view
+
+import (
+	"fmt"
+	"github.com/viant/datly/shared"
+	"reflect"
+	"strings"
+)
+
+const (
+	CountFunc = "count"
+	SumFunc   = "sum"
+	AvgFunc   = "avg"
+	MinFunc   = "min"
+	MaxFunc   = "max"
+)
+
+var aggregateFunctions = []string{CountFunc, SumFunc, AvgFunc, MinFunc, MaxFunc}
+
+type (
+	//Aggregation configures client driven aggregation (default ${NS}_groupby= and ${NS}_agg= query params)
+	Aggregation struct {
+		GroupBy   []string `json:",omitempty"` //columns allowed to group by, * allows all view columns
+		Functions []string `json:",omitempty"` //allowed aggregate functions, defaults to count, sum, avg, min, max
+	}
+
+	//Aggregate represents aggregate function applied to the view column
+	Aggregate struct {
+		Func   string
+		Column string `json:",omitempty"` //empty for count(*)
+		Name   string //result field and column name
+	}
+)
+
+//NewAggregate creates an Aggregate, column can be nil for count(*)
+func NewAggregate(fn string, column *Column) *Aggregate {
+	fn = strings.ToLower(fn)
+	aggregate := &Aggregate{Func: fn, Name: strings.Title(fn)}
+	if column != nil {
+		aggregate.Column = column.Name
+		aggregate.Name += column.FieldName()
+	}
+
+	return aggregate
+}
+
+func (a *Aggregation) init(aView *View) error {
+	for i, fn := range a.Functions {
+		a.Functions[i] = strings.ToLower(strings.TrimSpace(fn))
+		if !isAggregateFunction(a.Functions[i]) {
+			return fmt.Errorf("unsupported view %v aggregate function %v", aView.Name, fn)
+		}
+	}
+
+	for i, colName := range a.GroupBy {
+		if strings.TrimSpace(colName) == "*" {
+			a.GroupBy[i] = "*"
+			continue
+		}
+
+		column, err := aView._columns.Lookup(colName)
+		if err != nil {
+			return fmt.Errorf("invalid view: %v %w", aView.Name, err)
+		}
+		a.GroupBy[i] = column.Name
+	}
+
+	return nil
+}
+
+//CanGroupBy indicates if view can be grouped by given column
+func (a *Aggregation) CanGroupBy(column *Column) bool {
+	for _, colName := range a.GroupBy {
+		if colName == "*" || colName == column.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+//CanUse indicates if aggregate function can be used
+func (a *Aggregation) CanUse(fn string) bool {
+	fn = strings.ToLower(fn)
+	if len(a.Functions) == 0 {
+		return isAggregateFunction(fn)
+	}
+
+	for _, candidate := range a.Functions {
+		if candidate == fn {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAggregateFunction(fn string) bool {
+	for _, candidate := range aggregateFunctions {
+		if candidate == fn {
+			return true
+		}
+	}
+
+	return false
+}
+
+//IsAggregation indicates if Selector groups or aggregates view data
+func (s *Selector) IsAggregation() bool {
+	return len(s.GroupBy) > 0 || len(s.Aggregates) > 0
+}
+
+//AggregateType builds result type for the Selector GroupBy columns and Aggregates
+func (v *View) AggregateType(selector *Selector) (reflect.Type, error) {
+	rType := shared.Elem(v.Schema.Type())
+	fields := make([]reflect.StructField, 0, len(selector.GroupBy)+len(selector.Aggregates))
+	for _, colName := range selector.GroupBy {
+		column, err := v._columns.Lookup(colName)
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok := rType.FieldByName(column.FieldName())
+		if !ok {
+			return nil, fmt.Errorf("not found field %v at type %v", column.FieldName(), rType.String())
+		}
+
+		field.Index = nil
+		field.Offset = 0
+		fields = append(fields, field)
+	}
+
+	for _, aggregate := range selector.Aggregates {
+		aggType, err := v.aggregateFieldType(aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: aggregate.Name,
+			Type: aggType,
+			Tag:  reflect.StructTag(`sqlx:"name=` + aggregate.Name + `"`),
+		})
+	}
+
+	return reflect.PtrTo(reflect.StructOf(fields)), nil
+}
+
+func (v *View) aggregateFieldType(aggregate *Aggregate) (reflect.Type, error) {
+	switch aggregate.Func {
+	case CountFunc:
+		return intType, nil
+	case SumFunc, AvgFunc:
+		return reflect.PtrTo(reflect.TypeOf(0.0)), nil
+	}
+
+	column, err := v._columns.Lookup(aggregate.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	columnType := column.ColumnType()
+	if columnType.Kind() != reflect.Ptr {
+		columnType = reflect.PtrTo(columnType)
+	}
+
+	return columnType, nil
+}
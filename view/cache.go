@@ -7,7 +7,10 @@ import (
 	"github.com/viant/afs/option"
 	"github.com/viant/afs/url"
 	"github.com/viant/datly/converter"
+	"github.com/viant/datly/logger"
 	"github.com/viant/datly/shared"
+	"github.com/viant/datly/view/cache/mem"
+	"github.com/viant/datly/view/cache/redis"
 	"github.com/viant/sqlx/io/read/cache"
 	"github.com/viant/sqlx/io/read/cache/aerospike"
 	"github.com/viant/sqlx/io/read/cache/afs"
@@ -29,9 +32,13 @@ type (
 		TimeToLiveMs int
 		PartSize     int `json:",omitempty"`
 		AerospikeConfig
+		RedisConfig
+		MemConfig
 		Warmup *Warmup `json:",omitempty" yaml:",omitempty"`
 
 		newCache    func() (cache.Cache, error)
+		purge       func(ctx context.Context) error
+		counter     logger.Counter
 		initialized bool
 		mux         sync.Mutex
 	}
@@ -45,11 +52,30 @@ type (
 		ResetFailuresInMs       int `json:",omitempty"`
 	}
 
+	//RedisConfig configures redis://[:password@]host:port[/db] or TLS rediss:// Provider, Location is used as keys prefix
+	RedisConfig struct {
+		PoolSize int `json:",omitempty"` //max active connections, defaults to 10
+	}
+
+	//MemConfig configures mem Provider, process local LRU cache, limits apply to each view cache
+	MemConfig struct {
+		MaxEntries int   `json:",omitempty"` //defaults to 10000
+		MaxBytes   int64 `json:",omitempty"` //keys and values size, defaults to 64MB
+	}
+
 	Warmup struct {
 		IndexColumn string
 		IndexMeta   bool       `json:",omitempty"`
 		Connector   *Connector `json:",omitempty"`
 		Cases       []*CacheParameters
+		Schedule    *WarmupSchedule `json:",omitempty" yaml:",omitempty"`
+	}
+
+	//WarmupSchedule configures gateway scheduler re-running Warmup Cases before cached entries expire
+	WarmupSchedule struct {
+		IntervalMs      int    `json:",omitempty"` //scheduler run interval, defaults to TimeToLiveMs - RefreshAheadMs
+		RefreshAheadMs  int    `json:",omitempty"` //how long before TimeToLiveMs expires entries are refreshed, defaults to 10% of TimeToLiveMs
+		WatermarkColumn string `json:",omitempty"` //enables incremental mode, case is refreshed only if its rows changed or entries are about to expire
 	}
 
 	CacheParameters struct {
@@ -77,6 +103,9 @@ const (
 	defaultType   = ""
 	afsType       = "afs"
 	aerospikeType = "aerospike"
+	redisType     = redis.Scheme
+	redissType    = redis.SecureScheme
+	memType       = mem.Scheme
 )
 
 func (c *Cache) init(ctx context.Context, resource *Resource, aView *View) error {
@@ -95,7 +124,7 @@ func (c *Cache) init(ctx context.Context, resource *Resource, aView *View) error
 		return err
 	}
 
-	if c.Location == "" {
+	if c.Location == "" && c.scheme() != memType {
 		return fmt.Errorf("view %v cache Location can't be empty", viewName)
 	}
 
@@ -103,10 +132,13 @@ func (c *Cache) init(ctx context.Context, resource *Resource, aView *View) error
 		return fmt.Errorf("view %v cache TimeToLiveMs can't be empty", viewName)
 	}
 
+	c.ensureCounter(resource, viewName)
 	if err := c.ensureCacheClient(aView, viewName); err != nil {
 		return err
 	}
 
+	c.registerInvalidator(aView)
+
 	if err := c.initWarmup(ctx, resource); err != nil {
 		return err
 	}
@@ -132,11 +164,47 @@ func (c *Cache) ensureCacheClient(aView *View, viewName string) error {
 	return nil
 }
 
+//registerInvalidator registers cache purged once any of the View tags is invalidated
+func (c *Cache) registerInvalidator(aView *View) {
+	if aView == nil || c.purge == nil || len(aView.Tags) == 0 {
+		return
+	}
+
+	RegisterInvalidator(c.Provider+"|"+c.Location+"|"+aView.Name, c, aView.Tags...)
+}
+
+//ensureCounter registers cache hit, miss, eviction and error counters
+func (c *Cache) ensureCounter(resource *Resource, viewName string) {
+	if c.counter != nil || resource == nil || resource.Metrics == nil || viewName == "" {
+		return
+	}
+
+	metric := resource.Metrics
+	name := metric.operationName(viewName) + ".cache"
+	if operation := metric.Service.LookupOperation(name); operation != nil {
+		c.counter = operation
+		return
+	}
+
+	c.counter = metric.Service.MultiOperationCounter(metricLocation(), name, name+" cache", time.Millisecond, time.Minute, 2, cacheMetricProvider{})
+}
+
+func (c *Cache) scheme() string {
+	if c.Provider == memType {
+		return memType
+	}
+
+	return url.Scheme(c.Provider, "")
+}
+
 func (c *Cache) cacheService(name string, aView *View) (func() (cache.Cache, error), error) {
-	scheme := url.Scheme(c.Provider, "")
-	switch scheme {
+	switch c.scheme() {
 	case aerospikeType:
 		return c.aerospikeCache(aView)
+	case redisType, redissType:
+		return c.redisCache(aView)
+	case memType:
+		return c.memCache(aView)
 	default:
 		if aView.Name == "" {
 			return nil, nil
@@ -151,6 +219,10 @@ func (c *Cache) cacheService(name string, aView *View) (func() (cache.Cache, err
 			return nil, err
 		}
 
+		c.purge = func(ctx context.Context) error {
+			return purgeLocation(ctx, expandedLoc)
+		}
+
 		return func() (cache.Cache, error) {
 			return afsCache, nil
 		}, nil
@@ -187,6 +259,14 @@ func (c *Cache) aerospikeCache(aView *View) (func() (cache.Cache, error), error)
 	}
 
 	failureHandler := aerospike.NewFailureHandler(int64(c.AerospikeConfig.FailedRequestLimit), resetTimout)
+	c.purge = func(ctx context.Context) error {
+		client, err := clientProvider()
+		if err != nil {
+			return err
+		}
+
+		return client.Truncate(nil, namespace, expanded, nil)
+	}
 
 	return func() (cache.Cache, error) {
 		client, err := clientProvider()
@@ -198,6 +278,53 @@ func (c *Cache) aerospikeCache(aView *View) (func() (cache.Cache, error), error)
 	}, nil
 }
 
+func (c *Cache) redisCache(aView *View) (func() (cache.Cache, error), error) {
+	client, err := aRedisPool.Client(c.Provider, c.RedisConfig.PoolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := c.expandLocation(aView)
+	if err != nil {
+		return nil, err
+	}
+
+	redisCache := redis.New(client, prefix, time.Duration(c.TimeToLiveMs)*time.Millisecond, c.counterOptions()...)
+	c.purge = func(ctx context.Context) error {
+		_, err := redisCache.Purge(ctx)
+		return err
+	}
+	return func() (cache.Cache, error) {
+		return redisCache, nil
+	}, nil
+}
+
+func (c *Cache) memCache(aView *View) (func() (cache.Cache, error), error) {
+	prefix, err := c.expandLocation(aView)
+	if err != nil {
+		return nil, err
+	}
+
+	options := c.counterOptions()
+	store := aMemPool.Store(aView.Name+"#"+prefix, c.MemConfig.MaxEntries, c.MemConfig.MaxBytes, options...)
+	memCache := mem.New(store, prefix, time.Duration(c.TimeToLiveMs)*time.Millisecond, options...)
+	c.purge = func(ctx context.Context) error {
+		_, err := memCache.Purge(ctx)
+		return err
+	}
+	return func() (cache.Cache, error) {
+		return memCache, nil
+	}, nil
+}
+
+func (c *Cache) counterOptions() []interface{} {
+	if c.counter == nil {
+		return nil
+	}
+
+	return []interface{}{c.counter}
+}
+
 func (c *Cache) expandLocation(aView *View) (string, error) {
 	viewParam := AsViewParam(aView, nil, nil)
 	asBytes, err := json.Marshal(viewParam)
@@ -221,6 +348,15 @@ func (c *Cache) Service() (cache.Cache, error) {
 	return c.newCache()
 }
 
+//Purge removes all View cache entries
+func (c *Cache) Purge(ctx context.Context) error {
+	if c.purge == nil {
+		return nil
+	}
+
+	return c.purge(ctx)
+}
+
 func (c *Cache) split(location string) (host string, port int, namespace string, err error) {
 	actualScheme := url.Scheme(location, "")
 
@@ -297,6 +433,18 @@ func (c *Cache) inherit(source *Cache) error {
 		c.TimeToLiveMs = source.TimeToLiveMs
 	}
 
+	if c.RedisConfig.PoolSize == 0 {
+		c.RedisConfig.PoolSize = source.RedisConfig.PoolSize
+	}
+
+	if c.MemConfig.MaxEntries == 0 {
+		c.MemConfig.MaxEntries = source.MemConfig.MaxEntries
+	}
+
+	if c.MemConfig.MaxBytes == 0 {
+		c.MemConfig.MaxBytes = source.MemConfig.MaxBytes
+	}
+
 	return nil
 }
 
@@ -389,6 +537,10 @@ func (c *Cache) initWarmup(ctx context.Context, resource *Resource) error {
 		return fmt.Errorf("not found warmup column %v at view %v", c.Warmup, c.owner.Name)
 	}
 
+	if err := c.initWarmupSchedule(); err != nil {
+		return err
+	}
+
 	for _, dataset := range c.Warmup.Cases {
 
 		for _, paramValue := range dataset.Set {
@@ -407,6 +559,49 @@ func (c *Cache) initWarmup(ctx context.Context, resource *Resource) error {
 	return nil
 }
 
+func (c *Cache) initWarmupSchedule() error {
+	schedule := c.Warmup.Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	if schedule.RefreshAheadMs == 0 {
+		schedule.RefreshAheadMs = c.TimeToLiveMs / 10
+	}
+
+	if schedule.RefreshAheadMs < 0 || schedule.RefreshAheadMs >= c.TimeToLiveMs {
+		return fmt.Errorf("view %v warmup RefreshAheadMs has to be between 0 and TimeToLiveMs, but was %v", c.owner.Name, schedule.RefreshAheadMs)
+	}
+
+	if schedule.IntervalMs == 0 {
+		schedule.IntervalMs = c.TimeToLiveMs - schedule.RefreshAheadMs
+	}
+
+	if schedule.IntervalMs < 0 {
+		return fmt.Errorf("view %v warmup IntervalMs can't be negative, but was %v", c.owner.Name, schedule.IntervalMs)
+	}
+
+	if _, ok := c.owner.ColumnByName(schedule.WatermarkColumn); !ok && schedule.WatermarkColumn != "" {
+		return fmt.Errorf("not found warmup watermark column %v at view %v", schedule.WatermarkColumn, c.owner.Name)
+	}
+
+	return nil
+}
+
+//WarmupRefreshAfter returns how long scheduled warmup keeps unchanged entries before refreshing them
+func (c *Cache) WarmupRefreshAfter() time.Duration {
+	if c.Warmup == nil || c.Warmup.Schedule == nil {
+		return time.Duration(c.TimeToLiveMs) * time.Millisecond
+	}
+
+	return time.Duration(c.TimeToLiveMs-c.Warmup.Schedule.RefreshAheadMs) * time.Millisecond
+}
+
+//Interval returns scheduler run interval
+func (s *WarmupSchedule) Interval() time.Duration {
+	return time.Duration(s.IntervalMs) * time.Millisecond
+}
+
 func (c *Cache) ensureParam(paramValue *ParamValue) error {
 	if paramValue._param != nil {
 		return nil
@@ -0,0 +1,529 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/datly/logger"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/aerospike"
+	"github.com/viant/sqlx/io/read/cache/hash"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//HitKey counts entries read from the cache
+	HitKey = "hit"
+	//MissKey counts entries read from the database
+	MissKey = "miss"
+
+	purgeBatchSize = 1000
+)
+
+type (
+	//Store represents key value store used by the cache
+	Store interface {
+		Get(ctx context.Context, key string) ([]byte, error)
+		MGet(ctx context.Context, keys ...string) ([][]byte, error)
+		Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+		Del(ctx context.Context, keys ...string) error
+		Keys(ctx context.Context, prefix string) ([]string, error)
+	}
+
+	//Cache represents sqlx read cache backed by key value store
+	//each entry is stored as a single value: JSON encoded cache.Meta line followed by JSON encoded rows lines
+	Cache struct {
+		store      Store
+		prefix     string
+		ttl        time.Duration
+		recorder   cache.Recorder
+		counter    logger.Counter
+		typeHolder *cache.ScanTypeHolder
+		writers    sync.Map //*cache.Entry -> *writer
+		mux        sync.Mutex
+	}
+
+	//writer buffers entry rows, rows are stored with entry meta once entry is flushed
+	writer struct {
+		cache     *Cache
+		entry     *cache.Entry
+		buffer    bytes.Buffer
+		discarded bool
+	}
+
+	nopCloser struct{}
+)
+
+func (n nopCloser) Close() error {
+	return nil
+}
+
+//New creates cache, keys are prefixed with the prefix and expire after ttl
+//logger.Counter option counts HitKey and MissKey values
+func New(store Store, prefix string, ttl time.Duration, options ...interface{}) *Cache {
+	result := &Cache{store: store, prefix: prefix, ttl: ttl}
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case cache.Recorder:
+			result.recorder = actual
+		case logger.Counter:
+			result.counter = actual
+		}
+	}
+
+	return result
+}
+
+func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (*cache.Entry, error) {
+	var query *cache.ParmetrizedQuery
+	var stats *cache.Stats
+	for _, option := range options {
+		switch actual := option.(type) {
+		case *cache.ParmetrizedQuery:
+			query = actual
+		case *cache.Stats:
+			stats = actual
+		}
+	}
+
+	if stats == nil {
+		stats = &cache.Stats{}
+	}
+	stats.Init()
+
+	if c.counter == nil {
+		return c.get(ctx, SQL, args, query, stats)
+	}
+
+	onDone := c.counter.Begin(time.Now())
+	entry, err := c.get(ctx, SQL, args, query, stats)
+	switch {
+	case err != nil:
+		onDone(time.Now(), err)
+	case stats.Type == cache.TypeWrite:
+		onDone(time.Now(), MissKey)
+	default:
+		onDone(time.Now(), HitKey)
+	}
+
+	return entry, err
+}
+
+func (c *Cache) get(ctx context.Context, SQL string, args []interface{}, query *cache.ParmetrizedQuery, stats *cache.Stats) (*cache.Entry, error) {
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValue, err := hash.GenerateWithMarshal(SQL, "", "", argsMarshal)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &cache.Entry{Meta: cache.Meta{SQL: SQL, Args: argsMarshal}, Id: keyValue}
+	found, err := c.readEntry(ctx, entry, stats)
+	if err != nil || found {
+		return entry, err
+	}
+
+	if query != nil {
+		if found, err = c.readIndexed(ctx, entry, query, stats); err != nil || found {
+			return entry, err
+		}
+	}
+
+	aWriter := &writer{cache: c, entry: entry}
+	c.writers.Store(entry, aWriter)
+	entry.SetWriter(aWriter, aWriter)
+	stats.Type = cache.TypeWrite
+	return entry, nil
+}
+
+//readEntry reads entry cached by the previous query with the same SQL and args
+func (c *Cache) readEntry(ctx context.Context, entry *cache.Entry, stats *cache.Stats) (bool, error) {
+	value, err := c.store.Get(ctx, c.key(entry.Id))
+	if err != nil || value == nil {
+		return false, err
+	}
+
+	meta, data, err := c.decode(value)
+	if err != nil || !c.matches(meta, entry.Meta.SQL, entry.Meta.Args) {
+		return false, c.store.Del(ctx, c.key(entry.Id))
+	}
+
+	if err = c.assignMeta(entry, meta); err != nil {
+		return false, err
+	}
+
+	entry.SetReader(bufio.NewReader(bytes.NewReader(data)), nopCloser{})
+	stats.Type = cache.TypeReadSingle
+	stats.FoundLazy = true
+	stats.RecordsCounter = 1
+	stats.Key = entry.Id
+	return true, nil
+}
+
+//readIndexed reads entry from rows indexed by the ParmetrizedQuery column, see IndexBy
+func (c *Cache) readIndexed(ctx context.Context, entry *cache.Entry, query *cache.ParmetrizedQuery, stats *cache.Stats) (bool, error) {
+	query.Init()
+	argsMarshal, err := query.MarshalArgs()
+	if err != nil {
+		return false, err
+	}
+
+	keyValue, err := hash.GenerateWithMarshal(query.SQL, "", "", argsMarshal)
+	if err != nil {
+		return false, err
+	}
+
+	marker, err := c.store.Get(ctx, c.key(c.columnURL(query.By, keyValue)))
+	if err != nil || marker == nil {
+		return false, err
+	}
+
+	meta, _, err := c.decode(marker)
+	if err != nil || !c.matches(meta, query.SQL, argsMarshal) {
+		return false, err
+	}
+
+	keys := make([]string, 0, len(query.In))
+	for _, value := range query.In {
+		valueMarshal, err := json.Marshal(value)
+		if err != nil {
+			return false, err
+		}
+		keys = append(keys, c.key(c.columnValueURL(query.By, valueMarshal, keyValue)))
+	}
+
+	var values [][]byte
+	if len(keys) > 0 {
+		if values, err = c.store.MGet(ctx, keys...); err != nil {
+			return false, err
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		_, data, err := c.decode(value)
+		if err != nil {
+			return false, err
+		}
+		appendLines(buffer, data, query.Offset, query.Limit)
+	}
+
+	if err = c.assignMeta(entry, meta); err != nil {
+		return false, err
+	}
+
+	entry.SetReader(bufio.NewReader(buffer), nopCloser{})
+	stats.Type = cache.TypeReadMulti
+	stats.FoundWarmup = true
+	stats.RecordsCounter = len(keys)
+	stats.Key = keyValue
+	return true, nil
+}
+
+//appendLines appends data lines, skipping offset lines and taking at most limit lines, 0 limit means no limit
+func appendLines(buffer *bytes.Buffer, data []byte, offset, limit int) {
+	lines := bytes.Split(data, []byte{'\n'})
+	for i, line := range lines {
+		if len(line) == 0 || i < offset || (limit > 0 && i >= offset+limit) {
+			continue
+		}
+
+		if buffer.Len() > 0 {
+			buffer.WriteByte('\n')
+		}
+		buffer.Write(line)
+	}
+}
+
+func (c *Cache) AsSource(ctx context.Context, entry *cache.Entry) (cache.Source, error) {
+	return &Source{cache: c, entry: entry}, nil
+}
+
+func (c *Cache) AddValues(ctx context.Context, entry *cache.Entry, values []interface{}) error {
+	if c.recorder != nil {
+		c.recorder.AddValues(values)
+	}
+
+	marshal, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	return entry.Write(marshal)
+}
+
+func (c *Cache) AssignRows(entry *cache.Entry, rows *sql.Rows) error {
+	return entry.AssignRows(rows)
+}
+
+func (c *Cache) UpdateType(ctx context.Context, entry *cache.Entry, values []interface{}) (bool, error) {
+	c.mux.Lock()
+	if c.typeHolder == nil {
+		c.typeHolder = &cache.ScanTypeHolder{}
+		c.typeHolder.InitType(values)
+	}
+	c.mux.Unlock()
+
+	if !c.typeHolder.Match(entry) {
+		return false, c.Delete(ctx, entry)
+	}
+
+	return true, nil
+}
+
+func (c *Cache) Close(ctx context.Context, entry *cache.Entry) error {
+	if err := entry.Close(); err != nil {
+		_ = c.Delete(ctx, entry)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) error {
+	if value, ok := c.writers.Load(entry); ok {
+		value.(*writer).discarded = true
+	}
+
+	return c.store.Del(ctx, c.key(entry.Id))
+}
+
+func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
+	return c.Delete(ctx, entry)
+}
+
+//Purge removes all prefixed keys, entries being written are discarded, returns number of removed keys
+func (c *Cache) Purge(ctx context.Context) (int, error) {
+	c.writers.Range(func(_, value interface{}) bool {
+		value.(*writer).discarded = true
+		return true
+	})
+
+	keys, err := c.store.Keys(ctx, c.prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	for offset := 0; offset < len(keys); offset += purgeBatchSize {
+		end := offset + purgeBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if err = c.store.Del(ctx, keys[offset:end]...); err != nil {
+			return offset, err
+		}
+	}
+
+	return len(keys), nil
+}
+
+//IndexBy caches SQL rows grouped by the column value, used by the ParmetrizedQuery lookups
+//empty column caches all rows as the SQL entry
+func (c *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, args []interface{}) (int, error) {
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	querySQL, ordered := orderedSQL(SQL, column)
+	rows, err := db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	fields, err := cache.ColumnsToFields(io.TypesToColumns(columnTypes))
+	if err != nil {
+		return 0, err
+	}
+
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return 0, err
+	}
+
+	keyValue, err := hash.GenerateWithMarshal(SQL, "", "", argsMarshal)
+	if err != nil {
+		return 0, err
+	}
+
+	meta := &cache.Meta{SQL: SQL, Args: argsMarshal, Fields: fields}
+	indexed := make(chan *cache.Indexed, 512)
+	var indexErr error
+	go func() {
+		defer close(indexed)
+		indexErr = c.index(fields, column, rows, indexed, ordered)
+	}()
+
+	inserted := 0
+	var putErr error
+	for value := range indexed {
+		if putErr != nil || (value.ColumnValue == nil && column != "") {
+			continue
+		}
+
+		valueMarshal, err := json.Marshal(value.ColumnValue)
+		if err != nil {
+			putErr = err
+			continue
+		}
+
+		if putErr = c.put(ctx, c.columnValueURL(column, valueMarshal, keyValue), meta, value.Data.Bytes()); putErr == nil {
+			inserted++
+		}
+	}
+
+	if indexErr != nil {
+		return inserted, indexErr
+	}
+
+	if putErr != nil || column == "" {
+		return inserted, putErr
+	}
+
+	return inserted + 1, c.put(ctx, c.columnURL(column, keyValue), meta, nil)
+}
+
+func (c *Cache) index(fields []*cache.Field, column string, rows *sql.Rows, dest chan *cache.Indexed, ordered bool) error {
+	indexSource, err := aerospike.NewIndexSource(column, ordered, fields, dest)
+	if err != nil {
+		return err
+	}
+
+	placeholders := aerospike.NewPlaceholders(indexSource.ColumnIndex(), fields)
+	for rows.Next() {
+		if err = rows.Scan(placeholders.ScanPlaceholders()...); err != nil {
+			return err
+		}
+
+		columnValue, ok := placeholders.ColumnValue()
+		if !ok {
+			continue
+		}
+
+		indexed := indexSource.Index(columnValue)
+		indexed.Column = column
+		if err = indexed.StringifyData(placeholders.Values()); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	return indexSource.Close()
+}
+
+//orderedSQL orders SQL by the index column, so that rows can be indexed without being kept in memory
+func orderedSQL(SQL string, column string) (string, bool) {
+	if column == "" || strings.Contains(strings.ToLower(SQL), "order by") {
+		return SQL, false
+	}
+
+	return SQL + " ORDER BY " + column, true
+}
+
+func (c *Cache) put(ctx context.Context, keyValue string, meta *cache.Meta, data []byte) error {
+	stored := *meta
+	stored.TimeToLive = int(cache.Now().Add(c.ttl).UnixNano())
+	metaMarshal, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	value := make([]byte, 0, len(metaMarshal)+len(data)+1)
+	value = append(value, metaMarshal...)
+	value = append(value, '\n')
+	value = append(value, data...)
+	return c.store.Set(ctx, c.key(keyValue), value, c.ttl)
+}
+
+func (c *Cache) decode(value []byte) (*cache.Meta, []byte, error) {
+	metaData, data := value, []byte{}
+	if index := bytes.IndexByte(value, '\n'); index != -1 {
+		metaData, data = value[:index], value[index+1:]
+	}
+
+	meta := &cache.Meta{}
+	if err := json.Unmarshal(metaData, meta); err != nil {
+		return nil, nil, fmt.Errorf("invalid redis cache entry: %w", err)
+	}
+
+	return meta, data, nil
+}
+
+func (c *Cache) matches(meta *cache.Meta, SQL string, args []byte) bool {
+	return meta.SQL == SQL && bytes.Equal(meta.Args, args) && int(cache.Now().UnixNano()) <= meta.TimeToLive
+}
+
+func (c *Cache) assignMeta(entry *cache.Entry, meta *cache.Meta) error {
+	entry.Meta.Fields = meta.Fields
+	entry.Meta.TimeToLive = meta.TimeToLive
+	for _, field := range entry.Meta.Fields {
+		if err := field.Init(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) key(keyValue string) string {
+	return c.prefix + keyValue
+}
+
+func (c *Cache) columnURL(column, URL string) string {
+	return strings.ToLower(column) + "#" + URL
+}
+
+func (c *Cache) columnValueURL(column string, columnValueMarshal []byte, URL string) string {
+	if column == "" {
+		return URL
+	}
+
+	return strings.ToLower(column) + "#" + strconv.Quote(string(columnValueMarshal)) + "#" + URL
+}
+
+func (w *writer) Write(data []byte) (int, error) {
+	if w.buffer.Len() > 0 {
+		w.buffer.WriteByte('\n')
+	}
+
+	return w.buffer.Write(data)
+}
+
+//Flush stores buffered rows, entry is not stored before database columns are assigned or after it was deleted
+func (w *writer) Flush() error {
+	if w.discarded || len(w.entry.Meta.Fields) == 0 {
+		return nil
+	}
+
+	meta := &cache.Meta{SQL: w.entry.Meta.SQL, Args: w.entry.Meta.Args, Fields: w.entry.Meta.Fields}
+	return w.cache.put(context.Background(), w.entry.Id, meta, w.buffer.Bytes())
+}
+
+func (w *writer) Close() error {
+	w.cache.writers.Delete(w.entry)
+	w.buffer.Reset()
+	return nil
+}
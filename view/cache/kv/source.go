@@ -0,0 +1,57 @@
+package kv
+
+import (
+	"context"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/xunsafe"
+)
+
+//Source represents cached entry rows source
+type Source struct {
+	cache         *Cache
+	entry         *cache.Entry
+	scanner       cache.ScannerFn
+	columnsHolder *cache.ColumnsHolder
+	xtypesHolder  *cache.XTypesHolder
+}
+
+func (s *Source) ConvertColumns() ([]io.Column, error) {
+	if s.columnsHolder == nil {
+		s.columnsHolder = cache.NewColumnsHolder(s.entry)
+	}
+
+	return s.columnsHolder.ConvertColumns()
+}
+
+func (s *Source) Scanner(ctx context.Context) cache.ScannerFn {
+	if s.scanner == nil {
+		s.scanner = cache.NewScanner(s.cache.typeHolder, s.cache.recorder).New(s.entry)
+	}
+
+	return s.scanner
+}
+
+func (s *Source) XTypes() []*xunsafe.Type {
+	if s.xtypesHolder == nil {
+		s.xtypesHolder = cache.NewXTypeHolder(s.entry)
+	}
+
+	return s.xtypesHolder.XTypes()
+}
+
+func (s *Source) CheckType(ctx context.Context, values []interface{}) (bool, error) {
+	return s.cache.UpdateType(ctx, s.entry, values)
+}
+
+func (s *Source) Close(ctx context.Context) error {
+	return s.cache.Close(ctx, s.entry)
+}
+
+func (s *Source) Next() bool {
+	return s.entry.Next()
+}
+
+func (s *Source) Rollback(ctx context.Context) error {
+	return s.cache.Delete(ctx, s.entry)
+}
@@ -0,0 +1,14 @@
+package mem
+
+import (
+	"github.com/viant/datly/view/cache/kv"
+	"time"
+)
+
+//Scheme represents in-memory cache provider scheme
+const Scheme = "mem"
+
+//New creates in-memory cache, keys are prefixed with the prefix and expire after ttl
+func New(store *Store, prefix string, ttl time.Duration, options ...interface{}) *kv.Cache {
+	return kv.New(store, prefix, ttl, options...)
+}
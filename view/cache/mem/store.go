@@ -0,0 +1,176 @@
+package mem
+
+import (
+	"container/list"
+	"context"
+	"github.com/viant/datly/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//EvictionKey counts entries evicted to stay within the store limits
+	EvictionKey = "eviction"
+
+	defaultMaxEntries = 10000
+	defaultMaxBytes   = 64 * 1024 * 1024
+)
+
+type (
+	//Store represents process local key value store, least recently used values are evicted
+	//once store exceeds max entries or max bytes
+	Store struct {
+		mux        sync.Mutex
+		maxEntries int
+		maxBytes   int64
+		size       int64
+		items      *list.List
+		index      map[string]*list.Element
+		counter    logger.Counter
+	}
+
+	item struct {
+		key     string
+		value   []byte
+		expires time.Time
+	}
+)
+
+//NewStore creates LRU store, non-positive limits use defaults: 10000 entries and 64MB
+//logger.Counter option counts EvictionKey values
+func NewStore(maxEntries int, maxBytes int64, options ...interface{}) *Store {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	result := &Store{maxEntries: maxEntries, maxBytes: maxBytes, items: list.New(), index: map[string]*list.Element{}}
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case logger.Counter:
+			result.counter = actual
+		}
+	}
+
+	return result
+}
+
+//Get returns key value, nil if key does not exist or has expired
+func (s *Store) Get(_ context.Context, key string) ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.get(key, time.Now()), nil
+}
+
+//MGet returns keys values, nil for keys that do not exist or have expired
+func (s *Store) MGet(_ context.Context, keys ...string) ([][]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	now := time.Now()
+	result := make([][]byte, len(keys))
+	for i, key := range keys {
+		result[i] = s.get(key, now)
+	}
+
+	return result, nil
+}
+
+//Set sets key value, expiring after ttl, 0 ttl means no expiry; values exceeding max bytes are not stored
+func (s *Store) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if element, ok := s.index[key]; ok {
+		s.remove(element)
+	}
+
+	anItem := &item{key: key, value: value}
+	if anItem.size() > s.maxBytes {
+		return nil
+	}
+
+	if ttl > 0 {
+		anItem.expires = time.Now().Add(ttl)
+	}
+
+	s.index[key] = s.items.PushFront(anItem)
+	s.size += anItem.size()
+	for s.items.Len() > s.maxEntries || s.size > s.maxBytes {
+		s.remove(s.items.Back())
+		if s.counter != nil {
+			s.counter.IncrementValue(EvictionKey)
+		}
+	}
+
+	return nil
+}
+
+//Del removes keys
+func (s *Store) Del(_ context.Context, keys ...string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, key := range keys {
+		if element, ok := s.index[key]; ok {
+			s.remove(element)
+		}
+	}
+
+	return nil
+}
+
+//Keys returns keys with the prefix, empty prefix returns all keys
+func (s *Store) Keys(_ context.Context, prefix string) ([]string, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var result []string
+	for key := range s.index {
+		if strings.HasPrefix(key, prefix) {
+			result = append(result, key)
+		}
+	}
+
+	return result, nil
+}
+
+//Len returns number of stored values
+func (s *Store) Len() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.items.Len()
+}
+
+//Size returns stored keys and values size in bytes
+func (s *Store) Size() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.size
+}
+
+func (s *Store) get(key string, now time.Time) []byte {
+	element, ok := s.index[key]
+	if !ok {
+		return nil
+	}
+
+	anItem := element.Value.(*item)
+	if !anItem.expires.IsZero() && now.After(anItem.expires) {
+		s.remove(element)
+		return nil
+	}
+
+	s.items.MoveToFront(element)
+	return anItem.value
+}
+
+func (s *Store) remove(element *list.Element) {
+	anItem := s.items.Remove(element).(*item)
+	delete(s.index, anItem.key)
+	s.size -= anItem.size()
+}
+
+func (i *item) size() int64 {
+	return int64(len(i.key) + len(i.value))
+}
@@ -0,0 +1,124 @@
+package mem
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/gmetric/counter"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testCounter struct {
+	mux    sync.Mutex
+	values map[interface{}]int
+}
+
+func (c *testCounter) Begin(started time.Time) counter.OnDone {
+	return func(end time.Time, values ...interface{}) int64 {
+		for _, value := range values {
+			c.IncrementValue(value)
+		}
+		return 0
+	}
+}
+
+func (c *testCounter) DecrementValue(value interface{}) int64 {
+	return 0
+}
+
+func (c *testCounter) IncrementValue(value interface{}) int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.values == nil {
+		c.values = map[interface{}]int{}
+	}
+	c.values[value]++
+	return 0
+}
+
+func TestStore(t *testing.T) {
+	testcases := []struct {
+		description     string
+		maxEntries      int
+		maxBytes        int64
+		ttl             time.Duration
+		set             []string
+		get             []string
+		expectKeys      []string
+		expectEvictions int
+	}{
+		{
+			description: "within limits",
+			maxEntries:  3,
+			set:         []string{"k1", "k2", "k3"},
+			expectKeys:  []string{"k1", "k2", "k3"},
+		},
+		{
+			description:     "entries limit evicts least recently used",
+			maxEntries:      2,
+			set:             []string{"k1", "k2"},
+			get:             []string{"k1"},
+			expectKeys:      []string{"k1", "k3"},
+			expectEvictions: 1,
+		},
+		{
+			description:     "bytes limit evicts least recently used",
+			maxBytes:        10, //key and value of each entry takes 5 bytes
+			set:             []string{"k1", "k2"},
+			expectKeys:      []string{"k2", "k3"},
+			expectEvictions: 1,
+		},
+		{
+			description: "expired entries",
+			ttl:         time.Millisecond,
+			set:         []string{"k1", "k2"},
+			expectKeys:  []string{"k3"},
+		},
+	}
+
+	ctx := context.TODO()
+	for _, testcase := range testcases {
+		aCounter := &testCounter{}
+		store := NewStore(testcase.maxEntries, testcase.maxBytes, aCounter)
+		for _, key := range testcase.set {
+			assert.Nil(t, store.Set(ctx, key, []byte("v"+key), testcase.ttl), testcase.description)
+		}
+
+		if testcase.ttl > 0 {
+			time.Sleep(2 * testcase.ttl)
+		}
+
+		for _, key := range testcase.get {
+			_, _ = store.Get(ctx, key)
+		}
+
+		assert.Nil(t, store.Set(ctx, "k3", []byte("vk3"), 0), testcase.description)
+		var actualKeys []string
+		for _, key := range []string{"k1", "k2", "k3"} {
+			value, err := store.Get(ctx, key)
+			assert.Nil(t, err, testcase.description)
+			if value != nil {
+				assert.Equal(t, "v"+key, string(value), testcase.description)
+				actualKeys = append(actualKeys, key)
+			}
+		}
+
+		assert.Equal(t, testcase.expectKeys, actualKeys, testcase.description)
+		assert.Equal(t, testcase.expectEvictions, aCounter.values[EvictionKey], testcase.description)
+		assert.Equal(t, len(testcase.expectKeys), store.Len(), testcase.description)
+		assert.Equal(t, int64(5*len(testcase.expectKeys)), store.Size(), testcase.description)
+	}
+}
+
+func TestStore_MGet(t *testing.T) {
+	ctx := context.TODO()
+	store := NewStore(0, 0)
+	assert.Nil(t, store.Set(ctx, "k1", []byte("v1"), 0))
+	assert.Nil(t, store.Set(ctx, "k2", []byte("v2"), 0))
+	assert.Nil(t, store.Del(ctx, "k2"))
+
+	values, err := store.MGet(ctx, "k1", "k2")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("v1"), nil}, values)
+}
@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"github.com/viant/datly/view/cache/kv"
+	"time"
+)
+
+const (
+	//Scheme represents redis cache provider scheme
+	Scheme = "redis"
+	//SecureScheme represents redis over TLS cache provider scheme
+	SecureScheme = "rediss"
+)
+
+//New creates Redis cache, keys are prefixed with the prefix and expire after ttl
+func New(client *Client, prefix string, ttl time.Duration, options ...interface{}) *kv.Cache {
+	return kv.New(client, prefix, ttl, options...)
+}
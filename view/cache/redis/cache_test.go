@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/option"
+	"os"
+	"testing"
+	"time"
+)
+
+type event struct {
+	Id     int    `sqlx:"name=ID"`
+	Name   string `sqlx:"name=NAME"`
+	TypeId int    `sqlx:"name=TYPE_ID"`
+}
+
+func TestCache_Get(t *testing.T) {
+	dsn := "/tmp/datly_redis_cache_test.db"
+	_ = os.Remove(dsn)
+	db, err := sql.Open("sqlite3", dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	SQL := "SELECT ID, NAME, TYPE_ID FROM EVENTS"
+	testcases := []struct {
+		description string
+		indexBy     string
+		matcher     *cache.ParmetrizedQuery
+		expectFirst cache.Type
+		expectNext  cache.Type
+		expect      []*event
+	}{
+		{
+			description: "lazy entry",
+			expectFirst: cache.TypeWrite,
+			expectNext:  cache.TypeReadSingle,
+			expect:      []*event{{Id: 1, Name: "e1", TypeId: 1}, {Id: 2, Name: "e2", TypeId: 2}, {Id: 3, Name: "e3", TypeId: 2}},
+		},
+		{
+			description: "warmup index",
+			indexBy:     "TYPE_ID",
+			matcher:     &cache.ParmetrizedQuery{SQL: SQL, By: "TYPE_ID", In: []interface{}{2}},
+			expectFirst: cache.TypeReadMulti,
+			expectNext:  cache.TypeReadMulti,
+			expect:      []*event{{Id: 2, Name: "e2", TypeId: 2}, {Id: 3, Name: "e3", TypeId: 2}},
+		},
+		{
+			description: "warmup index with limit",
+			indexBy:     "TYPE_ID",
+			matcher:     &cache.ParmetrizedQuery{SQL: SQL, By: "TYPE_ID", In: []interface{}{1, 2}, Limit: 1},
+			expectFirst: cache.TypeReadMulti,
+			expectNext:  cache.TypeReadMulti,
+			expect:      []*event{{Id: 1, Name: "e1", TypeId: 1}, {Id: 2, Name: "e2", TypeId: 2}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		for _, initSQL := range []string{
+			"DROP TABLE IF EXISTS EVENTS",
+			"CREATE TABLE EVENTS (ID INTEGER PRIMARY KEY, NAME TEXT, TYPE_ID INTEGER)",
+			"INSERT INTO EVENTS VALUES (1, 'e1', 1), (2, 'e2', 2), (3, 'e3', 2)",
+		} {
+			_, err = db.Exec(initSQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		aServer, err := newServer("")
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		client, _ := NewClient(aServer.URL(), 0)
+		aCache := New(client, "datly:events:", time.Minute)
+		if testcase.indexBy != "" {
+			_, err = aCache.IndexBy(context.TODO(), db, testcase.indexBy, SQL, nil)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		for i, expectType := range []cache.Type{testcase.expectFirst, testcase.expectNext} {
+			stats := &cache.Stats{}
+			var options = []option.Option{aCache, stats}
+			if testcase.matcher != nil {
+				options = append(options, testcase.matcher)
+			}
+
+			reader, err := read.New(context.TODO(), db, SQL, func() interface{} { return &event{} }, options...)
+			if !assert.Nil(t, err, testcase.description) {
+				continue
+			}
+
+			var actual []*event
+			err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+				actual = append(actual, row.(*event))
+				return nil
+			})
+			assert.Nil(t, err, testcase.description)
+			assert.Equal(t, expectType, stats.Type, testcase.description)
+			assert.Equal(t, testcase.expect, actual, testcase.description)
+
+			if i == 0 { //next read has to be served from the cache
+				_, err = db.Exec("DELETE FROM EVENTS")
+				assert.Nil(t, err, testcase.description)
+			}
+		}
+
+		for _, key := range aServer.keys() {
+			assert.Contains(t, key, "datly:events:", testcase.description)
+		}
+
+		_ = client.Close()
+		_ = aServer.Close()
+	}
+}
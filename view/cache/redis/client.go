@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPoolSize    = 10
+	defaultDialTimeout = 5 * time.Second
+	scanCount          = 1000
+)
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+//Client represents Redis client with pooled connections
+type Client struct {
+	client *redis.Client
+}
+
+//NewClient creates client for redis://[:password@]host:port[/db] or TLS rediss://[:password@]host:port[/db] URL,
+//with at most poolSize active connections, commands wait for a free connection once the pool is exhausted
+func NewClient(URL string, poolSize int) (*Client, error) {
+	if !strings.HasPrefix(URL, Scheme+"://") && !strings.HasPrefix(URL, SecureScheme+"://") {
+		return nil, fmt.Errorf("unsupported redis URL format: %v, supported: redis[s]://[:password@]host:port[/db]", URL)
+	}
+
+	options, err := redis.ParseURL(URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL %v: %w", URL, err)
+	}
+
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+
+	options.PoolSize = poolSize
+	options.DialTimeout = defaultDialTimeout
+	return &Client{client: redis.NewClient(options)}, nil
+}
+
+//Do sends command and returns the server reply
+func (c *Client) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	return c.client.Do(ctx, args...).Result()
+}
+
+//Close closes client connections
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+//Get returns key value, nil if key does not exist
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+//MGet returns keys values, nil for keys that do not exist
+func (c *Client) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(keys) {
+		return nil, fmt.Errorf("unexpected redis MGET reply size %v, expected %v", len(values), len(keys))
+	}
+
+	result := make([][]byte, len(values))
+	for i, value := range values {
+		switch actual := value.(type) {
+		case nil:
+		case string:
+			result[i] = []byte(actual)
+		default:
+			return nil, fmt.Errorf("unexpected redis MGET value type %T", value)
+		}
+	}
+
+	return result, nil
+}
+
+//Set sets key value, key expires after ttl if ttl is greater than zero
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+//Del removes keys
+func (c *Client) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.client.Del(ctx, keys...).Err()
+}
+
+//Keys returns keys with the prefix, keys are iterated with SCAN to not block the server
+func (c *Client) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var result []string
+	iterator := c.client.Scan(ctx, 0, globEscaper.Replace(prefix)+"*", scanCount).Iterator()
+	for iterator.Next(ctx) {
+		result = append(result, iterator.Val())
+	}
+
+	return result, iterator.Err()
+}
@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	testcases := []struct {
+		description string
+		password    string
+		URL         func(aServer *server) string
+		expectErr   bool
+	}{
+		{
+			description: "anonymous connection",
+		},
+		{
+			description: "password connection",
+			password:    "secret",
+		},
+		{
+			description: "invalid password",
+			password:    "secret",
+			URL: func(aServer *server) string {
+				return "redis://:invalid@" + aServer.listener.Addr().String()
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		aServer, err := newServer(testcase.password)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		URL := aServer.URL()
+		if testcase.URL != nil {
+			URL = testcase.URL(aServer)
+		}
+
+		client, err := NewClient(URL, 2)
+		if !assert.Nil(t, err, testcase.description) {
+			_ = aServer.Close()
+			continue
+		}
+
+		ctx := context.TODO()
+		err = client.Set(ctx, "k1", []byte("v1"), 0)
+		assert.Equal(t, testcase.expectErr, err != nil, testcase.description)
+		if err != nil {
+			_ = aServer.Close()
+			continue
+		}
+
+		assert.Nil(t, client.Set(ctx, "k2", []byte("v2"), time.Millisecond), testcase.description)
+		time.Sleep(5 * time.Millisecond)
+
+		value, err := client.Get(ctx, "k1")
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, []byte("v1"), value, testcase.description)
+
+		values, err := client.MGet(ctx, "k1", "k2", "k3")
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, [][]byte{[]byte("v1"), nil, nil}, values, testcase.description)
+
+		assert.Nil(t, client.Set(ctx, "k*3", []byte("v3"), 0), testcase.description)
+		keys, err := client.Keys(ctx, "k")
+		assert.Nil(t, err, testcase.description)
+		assert.ElementsMatch(t, []string{"k1", "k*3"}, keys, testcase.description)
+		keys, err = client.Keys(ctx, "k*")
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, []string{"k*3"}, keys, testcase.description)
+		assert.Nil(t, client.Del(ctx, "k*3"), testcase.description)
+
+		assert.Nil(t, client.Del(ctx, "k1"), testcase.description)
+		value, err = client.Get(ctx, "k1")
+		assert.Nil(t, err, testcase.description)
+		assert.Nil(t, value, testcase.description)
+
+		_, err = client.Do(ctx, "UNKNOWN")
+		assert.NotNil(t, err, testcase.description)
+
+		assert.EqualValues(t, 1, atomic.LoadInt32(&aServer.conns), testcase.description)
+		assert.Nil(t, client.Close(), testcase.description)
+		_ = aServer.Close()
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	testcases := []struct {
+		description    string
+		URL            string
+		poolSize       int
+		expectPoolSize int
+		expectTLS      bool
+		expectErr      bool
+	}{
+		{description: "host and port", URL: "redis://127.0.0.1:6379", expectPoolSize: defaultPoolSize},
+		{description: "database", URL: "redis://127.0.0.1:6379/2", poolSize: 3, expectPoolSize: 3},
+		{description: "tls", URL: "rediss://:secret@127.0.0.1:6380/1", expectPoolSize: defaultPoolSize, expectTLS: true},
+		{description: "invalid database", URL: "redis://127.0.0.1:6379/abc", expectErr: true},
+		{description: "invalid scheme", URL: "aerospike://127.0.0.1:3000/test", expectErr: true},
+	}
+
+	for _, testcase := range testcases {
+		client, err := NewClient(testcase.URL, testcase.poolSize)
+		assert.Equal(t, testcase.expectErr, err != nil, testcase.description)
+		if err != nil {
+			continue
+		}
+
+		options := client.client.Options()
+		assert.Equal(t, testcase.expectPoolSize, options.PoolSize, testcase.description)
+		assert.Equal(t, testcase.expectTLS, options.TLSConfig != nil, testcase.description)
+		_ = client.Close()
+	}
+}
+
+func TestClient_PoolSize(t *testing.T) {
+	aServer, err := newServer("")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer aServer.Close()
+
+	client, err := NewClient(aServer.URL(), 2)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer client.Close()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.Nil(t, client.Set(context.TODO(), "k"+strconv.Itoa(i), []byte("v"), 0))
+		}(i)
+	}
+
+	wg.Wait()
+	assert.LessOrEqual(t, atomic.LoadInt32(&aServer.conns), int32(2))
+}
@@ -0,0 +1,247 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	//server represents in-process Redis protocol stand-in supporting commands used by the cache
+	server struct {
+		listener net.Listener
+		password string
+		mux      sync.Mutex
+		values   map[string]*value
+		conns    int32
+	}
+
+	value struct {
+		data    []byte
+		expires time.Time
+	}
+)
+
+func newServer(password string) (*server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &server{listener: listener, password: password, values: map[string]*value{}}
+	go result.serve()
+	return result, nil
+}
+
+func (s *server) URL() string {
+	if s.password != "" {
+		return "redis://:" + s.password + "@" + s.listener.Addr().String()
+	}
+
+	return "redis://" + s.listener.Addr().String()
+}
+
+func (s *server) Close() error {
+	return s.listener.Close()
+}
+
+func (s *server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		atomic.AddInt32(&s.conns, 1)
+		go s.handle(conn)
+	}
+}
+
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(args[0])
+		if !authenticated && command != "AUTH" {
+			_, _ = io.WriteString(conn, "-NOAUTH Authentication required.\r\n")
+			continue
+		}
+
+		switch command {
+		case "AUTH":
+			if len(args) != 2 || args[1] != s.password {
+				_, _ = io.WriteString(conn, "-WRONGPASS invalid password\r\n")
+				continue
+			}
+			authenticated = true
+			_, _ = io.WriteString(conn, "+OK\r\n")
+		case "PING":
+			_, _ = io.WriteString(conn, "+PONG\r\n")
+		case "SELECT":
+			_, _ = io.WriteString(conn, "+OK\r\n")
+		case "SET":
+			_, _ = io.WriteString(conn, s.set(args[1:]))
+		case "GET":
+			_, _ = io.WriteString(conn, bulk(s.get(args[1])))
+		case "MGET":
+			reply := "*" + strconv.Itoa(len(args)-1) + "\r\n"
+			for _, key := range args[1:] {
+				reply += bulk(s.get(key))
+			}
+			_, _ = io.WriteString(conn, reply)
+		case "DEL":
+			_, _ = io.WriteString(conn, ":"+strconv.Itoa(s.del(args[1:]))+"\r\n")
+		case "SCAN":
+			_, _ = io.WriteString(conn, s.scan(args[1:]))
+		default:
+			_, _ = io.WriteString(conn, "-ERR unknown command '"+args[0]+"'\r\n")
+		}
+	}
+}
+
+func (s *server) set(args []string) string {
+	if len(args) < 2 {
+		return "-ERR wrong number of arguments for 'set' command\r\n"
+	}
+
+	aValue := &value{data: []byte(args[1])}
+	if len(args) == 4 {
+		ttl, err := strconv.Atoi(args[3])
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+
+		unit := time.Millisecond
+		if strings.EqualFold(args[2], "EX") {
+			unit = time.Second
+		}
+		aValue.expires = time.Now().Add(time.Duration(ttl) * unit)
+	}
+
+	s.mux.Lock()
+	s.values[args[0]] = aValue
+	s.mux.Unlock()
+	return "+OK\r\n"
+}
+
+func (s *server) get(key string) []byte {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	aValue, ok := s.values[key]
+	if !ok {
+		return nil
+	}
+
+	if !aValue.expires.IsZero() && time.Now().After(aValue.expires) {
+		delete(s.values, key)
+		return nil
+	}
+
+	return aValue.data
+}
+
+func (s *server) del(keys []string) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	deleted := 0
+	for _, key := range keys {
+		if _, ok := s.values[key]; ok {
+			delete(s.values, key)
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+//scan returns all keys matching prefix* pattern at once
+func (s *server) scan(args []string) string {
+	prefix := ""
+	for i := 1; i+1 < len(args); i += 2 {
+		if strings.EqualFold(args[i], "MATCH") {
+			prefix = strings.TrimSuffix(args[i+1], "*")
+			prefix = strings.NewReplacer(`\\`, `\`, `\*`, "*", `\?`, "?", `\[`, "[", `\]`, "]").Replace(prefix)
+		}
+	}
+
+	var matched []string
+	for _, key := range s.keys() {
+		if strings.HasPrefix(key, prefix) {
+			matched = append(matched, key)
+		}
+	}
+
+	reply := "*2\r\n" + bulk([]byte("0")) + "*" + strconv.Itoa(len(matched)) + "\r\n"
+	for _, key := range matched {
+		reply += bulk([]byte(key))
+	}
+
+	return reply
+}
+
+func (s *server) keys() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var result []string
+	for key := range s.values {
+		result = append(result, key)
+	}
+
+	return result
+}
+
+func bulk(data []byte) string {
+	if data == nil {
+		return "$-1\r\n"
+	}
+
+	return "$" + strconv.Itoa(len(data)) + "\r\n" + string(data) + "\r\n"
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unsupported command %q", line)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+
+	return args, nil
+}
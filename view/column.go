@@ -35,6 +35,7 @@ type Column struct {
 	field         *reflect.StructField
 	initialized   bool
 	_fieldName    string
+	_filterRule   *FilterRule
 }
 
 //SqlExpression builds column sql expression if any expression specified in format: Expression AS Name
@@ -165,6 +166,17 @@ func (c *Column) defaultValue(rType reflect.Type) string {
 	}
 }
 
+//FilterRule returns column FilterRule, nil if column has no filter restrictions
+func (c *Column) FilterRule() *FilterRule {
+	return c._filterRule
+}
+
+//SetFilterRule marks column as filterable with given FilterRule
+func (c *Column) SetFilterRule(rule *FilterRule) {
+	c.Filterable = true
+	c._filterRule = rule
+}
+
 func (c *Column) FieldName() string {
 	return c._fieldName
 }
@@ -310,4 +322,5 @@ type ColumnConfig struct {
 	Codec      *Codec  `json:",omitempty"`
 	DataType   *string `json:",omitempty"`
 	Format     *string `json:",omitempty"`
+	Lock       *string `json:",omitempty"` //optimistic lock kind: version or updatedAt
 }
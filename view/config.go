@@ -14,6 +14,9 @@ const (
 	CriteriaQuery = "_criteria"
 	OrderByQuery  = "_orderby"
 	PageQuery     = "_page"
+	CursorQuery   = "_cursor"
+	GroupByQuery  = "_groupby"
+	AggQuery      = "_agg"
 )
 
 var intType = reflect.TypeOf(0)
@@ -34,6 +37,9 @@ type (
 		FieldsParam   *Parameter         `json:",omitempty"`
 		OrderByParam  *Parameter         `json:",omitempty"`
 		CriteriaParam *Parameter         `json:",omitempty"`
+		CursorParam   *Parameter         `json:",omitempty"`
+		GroupByParam  *Parameter         `json:",omitempty"`
+		AggParam      *Parameter         `json:",omitempty"`
 
 		limitDefault    *bool
 		offsetDefault   *bool
@@ -41,6 +47,9 @@ type (
 		fieldsDefault   *bool
 		criteriaDefault *bool
 		orderByDefault  *bool
+		cursorDefault   *bool
+		groupByDefault  *bool
+		aggDefault      *bool
 	}
 
 	SelectorParameter struct {
@@ -50,6 +59,9 @@ type (
 		Fields   string `json:",omitempty"`
 		OrderBy  string `json:",omitempty"`
 		Criteria string `json:",omitempty"`
+		Cursor   string `json:",omitempty"`
+		GroupBy  string `json:",omitempty"`
+		Agg      string `json:",omitempty"`
 	}
 )
 
@@ -75,6 +87,12 @@ func (c *Config) ParameterName(ns, paramName string) string {
 		result = c.Parameters.Criteria
 	case PageQuery:
 		result = c.Parameters.Page
+	case CursorQuery:
+		result = c.Parameters.Cursor
+	case GroupByQuery:
+		result = c.Parameters.GroupBy
+	case AggQuery:
+		result = c.Parameters.Agg
 	}
 	if result == "" {
 		return ns + paramName
@@ -122,6 +140,21 @@ func (c *Config) Init(ctx context.Context, resource *Resource, parent *View) err
 		c.OrderByParam = c.newSelectorParam(name, OrderByQuery, parent)
 	}
 
+	if name := parameters.Cursor; (name != "" || c.Constraints.Cursor) && derefBool(c.cursorDefault, c.CursorParam == nil) {
+		c.cursorDefault = boolPtr(name == "")
+		c.CursorParam = c.newSelectorParam(name, CursorQuery, parent)
+	}
+
+	if name := parameters.GroupBy; (name != "" || c.Constraints.Aggregation != nil) && derefBool(c.groupByDefault, c.GroupByParam == nil) {
+		c.groupByDefault = boolPtr(name == "")
+		c.GroupByParam = c.newSelectorParam(name, GroupByQuery, parent)
+	}
+
+	if name := parameters.Agg; (name != "" || c.Constraints.Aggregation != nil) && derefBool(c.aggDefault, c.AggParam == nil) {
+		c.aggDefault = boolPtr(name == "")
+		c.AggParam = c.newSelectorParam(name, AggQuery, parent)
+	}
+
 	if err := c.initCustomParams(ctx, resource, parent); err != nil {
 		return err
 	}
@@ -180,6 +213,18 @@ func (c *Config) initCustomParams(ctx context.Context, resource *Resource, paren
 		return err
 	}
 
+	if err := c.initParamIfNeeded(ctx, c.CursorParam, resource, stringType, parent); err != nil {
+		return err
+	}
+
+	if err := c.initParamIfNeeded(ctx, c.GroupByParam, resource, stringType, parent); err != nil {
+		return err
+	}
+
+	if err := c.initParamIfNeeded(ctx, c.AggParam, resource, stringType, parent); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -229,6 +274,12 @@ func Description(paramName, viewName string) string {
 		return fmt.Sprintf("allows to sort view %v results", viewName)
 	case PageQuery:
 		return fmt.Sprintf("allows to skip first page * limit values, starting from 1 page. Has precedence over offset")
+	case CursorQuery:
+		return fmt.Sprintf("allows to continue reading view %v data after the last record of the previous page, use NextCursor returned with the previous page", viewName)
+	case GroupByQuery:
+		return fmt.Sprintf("allows to group view %v data by given columns", viewName)
+	case AggQuery:
+		return fmt.Sprintf("allows to aggregate view %v data with count, sum, avg, min or max functions, i.e. sum(Price)", viewName)
 	}
 
 	return ""
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/viant/datly/shared"
+	"github.com/viant/datly/view/dialect"
 	"github.com/viant/scy"
 	"sync"
 	"time"
@@ -106,6 +107,11 @@ func (c *Connector) DB() (*sql.DB, error) {
 	return aDB, err
 }
 
+//Dialect returns SQL dialect registered for the connector driver
+func (c *Connector) Dialect() *dialect.Dialect {
+	return dialect.Lookup(c.Driver)
+}
+
 //Validate check if connector was configured properly.
 //Name, Driver and DSN are required.
 func (c *Connector) Validate() error {
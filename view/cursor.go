@@ -0,0 +1,329 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/datly/shared"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/xunsafe"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+//CursorKeyEnv environment variable used as cursor signing key if Constraints.CursorKey was not specified
+const CursorKeyEnv = "DATLY_CURSOR_KEY"
+
+type (
+	//Cursor represents keyset pagination position, last record ORDER BY column values
+	Cursor struct {
+		View    string        `json:"v"`
+		Columns []string      `json:"c"`
+		Values  []interface{} `json:"k"`
+	}
+
+	//SortColumn represents ORDER BY column
+	SortColumn struct {
+		Column *Column
+		Desc   bool
+	}
+)
+
+func (c *Constraints) initCursorKey() error {
+	if !c.Cursor {
+		return nil
+	}
+
+	key := FirstNotEmpty(c.CursorKey, os.Getenv(CursorKeyEnv))
+	if key == "" {
+		return fmt.Errorf("cursor signing key was empty, specify CursorKey or %v env variable", CursorKeyEnv)
+	}
+
+	c._cursorKey = []byte(key)
+	return nil
+}
+
+//SortColumns returns columns used to sort View data for given Selector
+//Constraints.PrimaryKey columns missing in ORDER BY are appended as the final tiebreaker, so that rows with
+//the same ORDER BY values are neither skipped nor repeated across pages
+func (v *View) SortColumns(selector *Selector) ([]*SortColumn, error) {
+	orderBy := v.Selector.OrderBy
+	if selector != nil && selector.OrderBy != "" {
+		orderBy = selector.OrderBy
+	}
+
+	primaryKey := v.primaryKey()
+	if strings.TrimSpace(orderBy) == "" && len(primaryKey) == 0 {
+		return nil, fmt.Errorf("view %v cursor pagination requires order by", v.Name)
+	}
+
+	parts := strings.Split(orderBy, ",")
+	result := make([]*SortColumn, 0, len(parts)+len(primaryKey))
+	for _, part := range parts {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		name := fields[0]
+		if index := strings.LastIndexByte(name, '.'); index != -1 {
+			name = name[index+1:]
+		}
+
+		column, ok := v.ColumnByName(name)
+		if !ok {
+			return nil, fmt.Errorf("not found column %v at view %v", name, v.Name)
+		}
+
+		result = append(result, &SortColumn{
+			Column: column,
+			Desc:   len(fields) > 1 && strings.EqualFold(fields[1], "DESC"),
+		})
+	}
+
+	for _, name := range primaryKey {
+		column, ok := v.ColumnByName(name)
+		if !ok {
+			return nil, fmt.Errorf("not found primary key column %v at view %v", name, v.Name)
+		}
+
+		if !hasSortColumn(result, column) {
+			result = append(result, &SortColumn{Column: column})
+		}
+	}
+
+	return result, nil
+}
+
+//HasCursorTiebreaker returns true if View data sorted for cursor pagination is ordered by primary key as the last resort
+func (v *View) HasCursorTiebreaker() bool {
+	return len(v.primaryKey()) > 0
+}
+
+func (v *View) primaryKey() []string {
+	if v.Selector == nil || v.Selector.Constraints == nil {
+		return nil
+	}
+
+	return v.Selector.Constraints.PrimaryKey
+}
+
+func hasSortColumn(sortColumns []*SortColumn, column *Column) bool {
+	for _, sortColumn := range sortColumns {
+		if sortColumn.Column == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+//ensureCursorPrimaryKey detects View.Table primary key used as cursor order tiebreaker unless Constraints.PrimaryKey was specified
+func (v *View) ensureCursorPrimaryKey(ctx context.Context) error {
+	constraints := v.Selector.Constraints
+	if constraints == nil || !constraints.Cursor {
+		return nil
+	}
+
+	if len(constraints.PrimaryKey) == 0 && v.Table != "" {
+		db, err := v.Db()
+		if err != nil {
+			return err
+		}
+
+		var keys []sink.Key
+		if err = metadata.New().Info(ctx, db, info.KindPrimaryKeys, &keys); err != nil {
+			return fmt.Errorf("failed to detect view %v primary key: %w", v.Name, err)
+		}
+
+		constraints.PrimaryKey = tablePrimaryKey(keys, v.Table)
+	}
+
+	if len(constraints.PrimaryKey) == 0 {
+		return fmt.Errorf("view %v cursor pagination requires primary key, specify Constraints.PrimaryKey", v.Name)
+	}
+
+	for _, name := range constraints.PrimaryKey {
+		if _, ok := v.ColumnByName(name); !ok {
+			return fmt.Errorf("not found primary key column %v at view %v", name, v.Name)
+		}
+	}
+
+	return nil
+}
+
+func tablePrimaryKey(keys []sink.Key, table string) []string {
+	if index := strings.LastIndexByte(table, '.'); index != -1 {
+		table = table[index+1:]
+	}
+
+	var tableKeys []sink.Key
+	for i, key := range keys {
+		if strings.EqualFold(key.Table, table) {
+			tableKeys = append(tableKeys, keys[i])
+		}
+	}
+
+	sort.Slice(tableKeys, func(i, j int) bool {
+		return tableKeys[i].Position < tableKeys[j].Position
+	})
+
+	result := make([]string, 0, len(tableKeys))
+	for _, key := range tableKeys {
+		result = append(result, key.Column)
+	}
+
+	return result
+}
+
+//EncodeCursor creates signed cursor token pointing after given record
+func (v *View) EncodeCursor(selector *Selector, record interface{}) (string, error) {
+	sortColumns, err := v.SortColumns(selector)
+	if err != nil {
+		return "", err
+	}
+
+	rType := shared.Elem(v.Schema.Type())
+	ptr := xunsafe.AsPointer(record)
+	cursor := &Cursor{View: v.Name}
+	for _, sortColumn := range sortColumns {
+		field := xunsafe.FieldByName(rType, sortColumn.Column.FieldName())
+		if field == nil {
+			return "", fmt.Errorf("not found field %v at type %v", sortColumn.Column.FieldName(), rType.String())
+		}
+
+		value := field.Value(ptr)
+		if field.Type.Kind() == reflect.Ptr && *(*unsafe.Pointer)(field.Pointer(ptr)) == nil {
+			value = nil
+		}
+
+		cursor.Columns = append(cursor.Columns, sortColumn.Column.Name)
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(v.signCursor(data)), nil
+}
+
+//DecodeCursor verifies cursor token signature and returns ORDER BY column values
+func (v *View) DecodeCursor(selector *Selector, token string) ([]interface{}, error) {
+	index := strings.IndexByte(token, '.')
+	if index == -1 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token[:index])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(token[index+1:])
+	if err != nil || !hmac.Equal(signature, v.signCursor(data)) {
+		return nil, fmt.Errorf("invalid cursor signature")
+	}
+
+	cursor := &Cursor{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	sortColumns, err := v.SortColumns(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.View != v.Name || len(cursor.Columns) != len(sortColumns) || len(cursor.Values) != len(sortColumns) {
+		return nil, fmt.Errorf("cursor does not match view %v order", v.Name)
+	}
+
+	for i, sortColumn := range sortColumns {
+		if cursor.Columns[i] != sortColumn.Column.Name {
+			return nil, fmt.Errorf("cursor does not match view %v order", v.Name)
+		}
+
+		if cursor.Values[i], err = cursorValue(sortColumn.Column, cursor.Values[i]); err != nil {
+			return nil, fmt.Errorf("invalid cursor %v value: %w", sortColumn.Column.Name, err)
+		}
+	}
+
+	return cursor.Values, nil
+}
+
+func (v *View) signCursor(data []byte) []byte {
+	mac := hmac.New(sha256.New, v.Selector.Constraints._cursorKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+//cursorValue converts decoded JSON cursor value into the column type value, so that i.e. time columns are not compared as strings
+func cursorValue(column *Column, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	rType := column.ColumnType()
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	if rType == nil {
+		if number, ok := value.(json.Number); ok {
+			return asNumber(number), nil
+		}
+
+		return value, nil
+	}
+
+	text := fmt.Sprintf("%v", value)
+	if rType == timeType {
+		return time.Parse(time.RFC3339Nano, text)
+	}
+
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		return strconv.ParseInt(text, 10, 64)
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return strconv.ParseUint(text, 10, 64)
+	case reflect.Float64, reflect.Float32:
+		return strconv.ParseFloat(text, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(text)
+	case reflect.String:
+		return text, nil
+	}
+
+	if number, ok := value.(json.Number); ok {
+		return asNumber(number), nil
+	}
+
+	return value, nil
+}
+
+func asNumber(number json.Number) interface{} {
+	if asInt, err := number.Int64(); err == nil {
+		return asInt
+	}
+
+	if asFloat, err := number.Float64(); err == nil {
+		return asFloat
+	}
+
+	return number.String()
+}
@@ -0,0 +1,82 @@
+package view
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestView_DecodeCursor(t *testing.T) {
+	type event struct {
+		Id      int
+		Created time.Time
+		Updated *time.Time
+		Name    string
+	}
+
+	created := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	testcases := []struct {
+		description string
+		orderBy     string
+		primaryKey  []string
+		record      *event
+		expect      []interface{}
+	}{
+		{
+			description: "int column",
+			orderBy:     "Id",
+			record:      &event{Id: 5},
+			expect:      []interface{}{int64(5)},
+		},
+		{
+			description: "time and string columns",
+			orderBy:     "Created DESC, Name",
+			record:      &event{Created: created, Name: "123"},
+			expect:      []interface{}{created, "123"},
+		},
+		{
+			description: "null column",
+			orderBy:     "Updated, Id",
+			record:      &event{Id: 7},
+			expect:      []interface{}{nil, int64(7)},
+		},
+		{
+			description: "primary key tiebreaker",
+			orderBy:     "Name DESC",
+			primaryKey:  []string{"Id"},
+			record:      &event{Id: 3, Name: "abc"},
+			expect:      []interface{}{"abc", int64(3)},
+		},
+		{
+			description: "primary key in order by",
+			orderBy:     "Id DESC",
+			primaryKey:  []string{"Id"},
+			record:      &event{Id: 3},
+			expect:      []interface{}{int64(3)},
+		},
+	}
+
+	for _, testcase := range testcases {
+		aView := &View{
+			Name:     "events",
+			Schema:   NewSchema(reflect.TypeOf(&event{})),
+			Selector: &Config{OrderBy: testcase.orderBy, Constraints: &Constraints{PrimaryKey: testcase.primaryKey, _cursorKey: []byte("secret")}},
+		}
+
+		for _, name := range []string{"Id", "Created", "Updated", "Name"} {
+			field, _ := reflect.TypeOf(event{}).FieldByName(name)
+			aView.Columns = append(aView.Columns, &Column{Name: name, rType: field.Type, _fieldName: name, Nullable: name == "Updated"})
+		}
+		aView._columns = Columns(aView.Columns).Index(aView.Caser)
+
+		token, err := aView.EncodeCursor(nil, testcase.record)
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		values, err := aView.DecodeCursor(nil, token)
+		assert.Nil(t, err, testcase.description)
+		assert.Equal(t, testcase.expect, values, testcase.description)
+	}
+}
@@ -0,0 +1,318 @@
+package dialect
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	//LimitOffset pagination uses LIMIT n OFFSET m suffix
+	LimitOffset = Pagination("LIMIT")
+	//FetchFirst pagination uses OFFSET m ROWS FETCH FIRST n ROWS ONLY suffix
+	FetchFirst = Pagination("FETCH")
+	//RowNum pagination wraps query with ROWNUM predicate
+	RowNum = Pagination("ROWNUM")
+	//Top pagination uses SELECT TOP n, falls back to FetchFirst if offset is used
+	Top = Pagination("TOP")
+
+	//QuestionMark placeholder style: ?
+	QuestionMark = Placeholder("?")
+	//Dollar placeholder style: $1, $2 ...
+	Dollar = Placeholder("$")
+	//Colon placeholder style: :1, :2 ...
+	Colon = Placeholder(":")
+	//AtP placeholder style: @p1, @p2 ...
+	AtP = Placeholder("@p")
+)
+
+type (
+	//Pagination represents pagination syntax
+	Pagination string
+
+	//Placeholder represents bind placeholder style
+	Placeholder string
+
+	//Dialect represents database specific SQL syntax used by generated SQL
+	Dialect struct {
+		Name            string
+		Pagination      Pagination
+		Placeholder     Placeholder
+		QuoteStart      string
+		QuoteEnd        string
+		RequiresOrderBy bool //i.e. SQL Server requires ORDER BY with OFFSET ... FETCH
+		NullsFirst      bool //NULL values are sorted before other values in ascending order
+		Keywords        map[string]bool
+		Explain         string //statement prefix returning query plan, empty if not supported
+		Upsert          Upsert //insert or update statement syntax, empty if not supported
+		Dual            string //table used to select values without a table, i.e. DUAL
+		True            string //boolean true literal, i.e. 1 for dialects without boolean type
+		False           string //boolean false literal, i.e. 0 for dialects without boolean type
+	}
+)
+
+//Paginate returns pagination fragment appended after ORDER BY clause
+func (d *Dialect) Paginate(limit, offset int) string {
+	switch d.Pagination {
+	case RowNum:
+		return ""
+	case Top:
+		if offset == 0 {
+			return ""
+		}
+		return d.fetchFirst(limit, offset)
+	case FetchFirst:
+		return d.fetchFirst(limit, offset)
+	}
+
+	sb := strings.Builder{}
+	if limit != 0 {
+		sb.WriteString(" LIMIT ")
+		sb.WriteString(strconv.Itoa(limit))
+	}
+
+	if offset != 0 {
+		sb.WriteString(" OFFSET ")
+		sb.WriteString(strconv.Itoa(offset))
+	}
+
+	return sb.String()
+}
+
+func (d *Dialect) fetchFirst(limit int, offset int) string {
+	sb := strings.Builder{}
+	if offset != 0 || (d.RequiresOrderBy && limit != 0) {
+		sb.WriteString(" OFFSET ")
+		sb.WriteString(strconv.Itoa(offset))
+		sb.WriteString(" ROWS")
+	}
+
+	if limit != 0 {
+		sb.WriteString(" FETCH FIRST ")
+		sb.WriteString(strconv.Itoa(limit))
+		sb.WriteString(" ROWS ONLY")
+	}
+
+	return sb.String()
+}
+
+//NeedsOrderBy returns true if pagination fragment requires ORDER BY clause
+func (d *Dialect) NeedsOrderBy(limit, offset int) bool {
+	if !d.RequiresOrderBy || (limit == 0 && offset == 0) {
+		return false
+	}
+
+	return d.Pagination == FetchFirst || (d.Pagination == Top && offset != 0)
+}
+
+//DefaultOrderBy returns ORDER BY clause used when dialect requires one, but none was specified
+func (d *Dialect) DefaultOrderBy() string {
+	return " ORDER BY (SELECT NULL)"
+}
+
+//Top returns TOP n fragment placed right after SELECT keyword
+func (d *Dialect) Top(limit, offset int) string {
+	if d.Pagination != Top || limit == 0 || offset != 0 {
+		return ""
+	}
+
+	return "TOP " + strconv.Itoa(limit) + " "
+}
+
+//WrapPagination wraps SQL with ROWNUM predicates if dialect uses RowNum pagination
+//columns are SQL result columns selected by the outer query, so that ROWNUM alias is not returned
+func (d *Dialect) WrapPagination(SQL string, limit, offset int, columns ...string) string {
+	if d.Pagination != RowNum || (limit == 0 && offset == 0) {
+		return SQL
+	}
+
+	if offset == 0 {
+		return "SELECT * FROM (" + SQL + ") WHERE ROWNUM <= " + strconv.Itoa(limit)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("SELECT ")
+	if len(columns) == 0 {
+		sb.WriteString("*")
+	}
+
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column)
+	}
+
+	sb.WriteString(" FROM (SELECT t_.*, ROWNUM rn_ FROM (")
+	sb.WriteString(SQL)
+	sb.WriteString(") t_")
+	if limit != 0 {
+		sb.WriteString(" WHERE ROWNUM <= ")
+		sb.WriteString(strconv.Itoa(offset + limit))
+	}
+	sb.WriteString(") WHERE rn_ > ")
+	sb.WriteString(strconv.Itoa(offset))
+	return sb.String()
+}
+
+//Quote quotes identifier if it is reserved keyword or is not a simple identifier
+func (d *Dialect) Quote(identifier string) string {
+	if identifier == "" || d.QuoteStart == "" || !d.needsQuote(identifier) {
+		return identifier
+	}
+
+	return d.QuoteStart + strings.ReplaceAll(identifier, d.QuoteEnd, d.QuoteEnd+d.QuoteEnd) + d.QuoteEnd
+}
+
+func (d *Dialect) needsQuote(identifier string) bool {
+	if strings.HasPrefix(identifier, d.QuoteStart) {
+		return false
+	}
+
+	if isReserved(d.Keywords, identifier) {
+		return true
+	}
+
+	for i, r := range identifier {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+//ExplainSQL returns SQL returning query plan of given SQL, false if dialect does not support it
+func (d *Dialect) ExplainSQL(SQL string) (string, bool) {
+	if d.Explain == "" {
+		return "", false
+	}
+
+	return d.Explain + " " + SQL, true
+}
+
+//EnsurePlaceholders converts '?' placeholders into dialect specific placeholders
+//Placeholders within quoted literals and identifiers are left unchanged
+func (d *Dialect) EnsurePlaceholders(SQL string) string {
+	if d.Placeholder == "" || d.Placeholder == QuestionMark || !strings.Contains(SQL, "?") {
+		return SQL
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(SQL) + 16)
+	counter := 0
+	var quote byte
+	for i := 0; i < len(SQL); i++ {
+		c := SQL[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			counter++
+			sb.WriteString(string(d.Placeholder))
+			sb.WriteString(strconv.Itoa(counter))
+			continue
+		}
+
+		sb.WriteByte(c)
+	}
+
+	return sb.String()
+}
+
+//Bool returns dialect specific boolean literal
+func (d *Dialect) Bool(value bool) string {
+	literal := d.False
+	if value {
+		literal = d.True
+	}
+
+	if literal == "" {
+		return strings.ToUpper(strconv.FormatBool(value))
+	}
+
+	return literal
+}
+
+//Predicate returns dialect specific always true or always false condition
+func (d *Dialect) Predicate(value bool) string {
+	return d.Bool(true) + " = " + d.Bool(value)
+}
+
+//InlineBools replaces '?' placeholders bound to boolean args with dialect boolean literals
+//SQL and args are returned unchanged if placeholders do not match args
+func (d *Dialect) InlineBools(SQL string, args []interface{}) (string, []interface{}) {
+	if !hasBool(args) {
+		return SQL, args
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(SQL))
+	actualArgs := make([]interface{}, 0, len(args))
+	counter := 0
+	var quote byte
+	for i := 0; i < len(SQL); i++ {
+		c := SQL[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			if counter >= len(args) {
+				return SQL, args
+			}
+
+			arg := args[counter]
+			counter++
+			if value, ok := asBool(arg); ok {
+				sb.WriteString(d.Bool(value))
+				continue
+			}
+
+			actualArgs = append(actualArgs, arg)
+		}
+
+		sb.WriteByte(c)
+	}
+
+	if counter != len(args) {
+		return SQL, args
+	}
+
+	return sb.String(), actualArgs
+}
+
+func hasBool(args []interface{}) bool {
+	for _, arg := range args {
+		if _, ok := asBool(arg); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func asBool(arg interface{}) (bool, bool) {
+	switch actual := arg.(type) {
+	case bool:
+		return actual, true
+	case *bool:
+		if actual != nil {
+			return *actual, true
+		}
+	}
+
+	return false, false
+}
@@ -0,0 +1,318 @@
+package dialect
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDialect_Paginate(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		SQL         string
+		limit       int
+		offset      int
+		columns     []string
+		expect      string
+	}{
+		{
+			description: "mysql limit offset",
+			driver:      "mysql",
+			SQL:         "SELECT ID FROM events",
+			limit:       10,
+			offset:      5,
+			expect:      "SELECT ID FROM events LIMIT 10 OFFSET 5",
+		},
+		{
+			description: "sql server top",
+			driver:      "sqlserver",
+			SQL:         "SELECT ID FROM events",
+			limit:       10,
+			expect:      "SELECT TOP 10 ID FROM events",
+		},
+		{
+			description: "sql server offset fetch",
+			driver:      "sqlserver",
+			SQL:         "SELECT ID FROM events",
+			limit:       10,
+			offset:      5,
+			expect:      "SELECT ID FROM events ORDER BY (SELECT NULL) OFFSET 5 ROWS FETCH FIRST 10 ROWS ONLY",
+		},
+		{
+			description: "oracle rownum",
+			driver:      "godror",
+			SQL:         "SELECT ID FROM events",
+			limit:       10,
+			offset:      5,
+			columns:     []string{"ID"},
+			expect:      "SELECT ID FROM (SELECT t_.*, ROWNUM rn_ FROM (SELECT ID FROM events) t_ WHERE ROWNUM <= 15) WHERE rn_ > 5",
+		},
+		{
+			description: "oracle rownum limit",
+			driver:      "godror",
+			SQL:         "SELECT ID, NAME FROM events",
+			limit:       10,
+			columns:     []string{"ID", "NAME"},
+			expect:      "SELECT * FROM (SELECT ID, NAME FROM events) WHERE ROWNUM <= 10",
+		},
+	}
+
+	for _, testCase := range testCases {
+		aDialect := Lookup(testCase.driver)
+		SQL := "SELECT " + aDialect.Top(testCase.limit, testCase.offset) + testCase.SQL[len("SELECT "):]
+		if aDialect.NeedsOrderBy(testCase.limit, testCase.offset) {
+			SQL += aDialect.DefaultOrderBy()
+		}
+		SQL += aDialect.Paginate(testCase.limit, testCase.offset)
+		SQL = aDialect.WrapPagination(SQL, testCase.limit, testCase.offset, testCase.columns...)
+		assert.Equal(t, testCase.expect, SQL, testCase.description)
+	}
+}
+
+func TestDialect_Quote(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		identifier  string
+		expect      string
+	}{
+		{description: "plain identifier", driver: "mysql", identifier: "user_id", expect: "user_id"},
+		{description: "mysql reserved", driver: "mysql", identifier: "order", expect: "`order`"},
+		{description: "postgres reserved", driver: "postgres", identifier: "user", expect: `"user"`},
+		{description: "sql server space", driver: "sqlserver", identifier: "first name", expect: "[first name]"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Lookup(testCase.driver).Quote(testCase.identifier), testCase.description)
+	}
+}
+
+func TestDialect_EnsurePlaceholders(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		SQL         string
+		expect      string
+	}{
+		{
+			description: "question mark",
+			driver:      "mysql",
+			SQL:         "SELECT * FROM foo WHERE ID = ? AND Name = ?",
+			expect:      "SELECT * FROM foo WHERE ID = ? AND Name = ?",
+		},
+		{
+			description: "dollar",
+			driver:      "postgres",
+			SQL:         "SELECT * FROM foo WHERE ID = ? AND Name = '?' AND Kind IN (?, ?)",
+			expect:      "SELECT * FROM foo WHERE ID = $1 AND Name = '?' AND Kind IN ($2, $3)",
+		},
+		{
+			description: "colon",
+			driver:      "oracle",
+			SQL:         "SELECT * FROM foo WHERE ID = ?",
+			expect:      "SELECT * FROM foo WHERE ID = :1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Lookup(testCase.driver).EnsurePlaceholders(testCase.SQL), testCase.description)
+	}
+}
+
+func TestDialect_UpsertSQL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		columns     []string
+		keys        []string
+		expect      string
+		expectErr   bool
+	}{
+		{
+			description: "postgres on conflict",
+			driver:      "postgres",
+			columns:     []string{"ID", "NAME"},
+			keys:        []string{"ID"},
+			expect:      "INSERT INTO events (ID, NAME) VALUES (?, ?) ON CONFLICT (ID) DO UPDATE SET NAME = excluded.NAME",
+		},
+		{
+			description: "sqlite keys only",
+			driver:      "sqlite3",
+			columns:     []string{"ID"},
+			keys:        []string{"ID"},
+			expect:      "INSERT INTO events (ID) VALUES (?) ON CONFLICT (ID) DO NOTHING",
+		},
+		{
+			description: "mysql on duplicate key",
+			driver:      "mysql",
+			columns:     []string{"ID", "NAME"},
+			keys:        []string{"ID"},
+			expect:      "INSERT INTO events (ID, NAME) VALUES (?, ?) ON DUPLICATE KEY UPDATE NAME = VALUES(NAME)",
+		},
+		{
+			description: "bigquery merge",
+			driver:      "bigquery",
+			columns:     []string{"ID", "NAME"},
+			keys:        []string{"ID"},
+			expect:      "MERGE INTO events t USING (SELECT ? AS ID, ? AS NAME) s ON (t.ID = s.ID) WHEN MATCHED THEN UPDATE SET NAME = s.NAME WHEN NOT MATCHED THEN INSERT (ID, NAME) VALUES (s.ID, s.NAME)",
+		},
+		{
+			description: "oracle merge",
+			driver:      "godror",
+			columns:     []string{"ID"},
+			keys:        []string{"ID"},
+			expect:      "MERGE INTO events t USING (SELECT ? AS ID FROM DUAL) s ON (t.ID = s.ID) WHEN NOT MATCHED THEN INSERT (ID) VALUES (s.ID)",
+		},
+		{
+			description: "unknown driver",
+			driver:      "unknown",
+			columns:     []string{"ID"},
+			keys:        []string{"ID"},
+			expectErr:   true,
+		},
+		{
+			description: "missing key column",
+			driver:      "mysql",
+			columns:     []string{"NAME"},
+			keys:        []string{"ID"},
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		values := make([]string, len(testCase.columns))
+		for i := range values {
+			values[i] = "?"
+		}
+
+		SQL, err := Lookup(testCase.driver).UpsertSQL("events", testCase.columns, testCase.keys, values)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, SQL, testCase.description)
+	}
+}
+
+func TestDialect_Bool(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		value       bool
+		expect      string
+	}{
+		{
+			description: "mysql true",
+			driver:      "mysql",
+			value:       true,
+			expect:      "TRUE",
+		},
+		{
+			description: "oracle true",
+			driver:      "oracle",
+			value:       true,
+			expect:      "1",
+		},
+		{
+			description: "sqlserver false",
+			driver:      "sqlserver",
+			value:       false,
+			expect:      "0",
+		},
+		{
+			description: "unknown driver false",
+			driver:      "unknown",
+			value:       false,
+			expect:      "FALSE",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Lookup(testCase.driver).Bool(testCase.value), testCase.description)
+	}
+}
+
+func TestDialect_InlineBools(t *testing.T) {
+	active := false
+	var testCases = []struct {
+		description string
+		driver      string
+		SQL         string
+		args        []interface{}
+		expect      string
+		expectArgs  []interface{}
+	}{
+		{
+			description: "no booleans",
+			driver:      "oracle",
+			SQL:         "SELECT * FROM foo WHERE ID = ?",
+			args:        []interface{}{1},
+			expect:      "SELECT * FROM foo WHERE ID = ?",
+			expectArgs:  []interface{}{1},
+		},
+		{
+			description: "oracle booleans",
+			driver:      "oracle",
+			SQL:         "SELECT * FROM foo WHERE ID = ? AND Name = '?' AND Active = ? AND Deleted = ?",
+			args:        []interface{}{1, true, &active},
+			expect:      "SELECT * FROM foo WHERE ID = ? AND Name = '?' AND Active = 1 AND Deleted = 0",
+			expectArgs:  []interface{}{1},
+		},
+		{
+			description: "postgres booleans",
+			driver:      "postgres",
+			SQL:         "UPDATE foo SET Active = ? WHERE ID = ?",
+			args:        []interface{}{true, 2},
+			expect:      "UPDATE foo SET Active = TRUE WHERE ID = ?",
+			expectArgs:  []interface{}{2},
+		},
+		{
+			description: "placeholders mismatch",
+			driver:      "oracle",
+			SQL:         "SELECT * FROM foo WHERE Active = ?",
+			args:        []interface{}{true, 2},
+			expect:      "SELECT * FROM foo WHERE Active = ?",
+			expectArgs:  []interface{}{true, 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		SQL, args := Lookup(testCase.driver).InlineBools(testCase.SQL, testCase.args)
+		assert.Equal(t, testCase.expect, SQL, testCase.description)
+		assert.Equal(t, testCase.expectArgs, args, testCase.description)
+	}
+}
+
+func TestDialect_Predicate(t *testing.T) {
+	var testCases = []struct {
+		description string
+		driver      string
+		value       bool
+		expect      string
+	}{
+		{
+			description: "postgres false",
+			driver:      "postgres",
+			value:       false,
+			expect:      "TRUE = FALSE",
+		},
+		{
+			description: "sqlserver false",
+			driver:      "sqlserver",
+			value:       false,
+			expect:      "1 = 0",
+		},
+		{
+			description: "oracle true",
+			driver:      "oracle",
+			value:       true,
+			expect:      "1 = 1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Lookup(testCase.driver).Predicate(testCase.value), testCase.description)
+	}
+}
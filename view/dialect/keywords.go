@@ -0,0 +1,21 @@
+package dialect
+
+import "strings"
+
+//reserved represents SQL keywords commonly reserved across databases
+var reserved = map[string]bool{
+	"ALL": true, "AND": true, "AS": true, "ASC": true, "BETWEEN": true, "BY": true, "CASE": true, "CHECK": true,
+	"COLUMN": true, "CONSTRAINT": true, "CREATE": true, "CROSS": true, "CURRENT": true, "DEFAULT": true, "DELETE": true,
+	"DESC": true, "DISTINCT": true, "DROP": true, "ELSE": true, "END": true, "EXISTS": true, "FALSE": true, "FETCH": true,
+	"FOR": true, "FOREIGN": true, "FROM": true, "FULL": true, "GRANT": true, "GROUP": true, "HAVING": true, "IN": true,
+	"INDEX": true, "INNER": true, "INSERT": true, "INTERVAL": true, "INTO": true, "IS": true, "JOIN": true, "KEY": true,
+	"LEFT": true, "LIKE": true, "LIMIT": true, "NOT": true, "NULL": true, "OFFSET": true, "ON": true, "OR": true,
+	"ORDER": true, "OUTER": true, "PRIMARY": true, "REFERENCES": true, "RIGHT": true, "ROW": true, "ROWS": true,
+	"SELECT": true, "SET": true, "TABLE": true, "THEN": true, "TO": true, "TOP": true, "TRUE": true, "UNION": true,
+	"UNIQUE": true, "UPDATE": true, "USER": true, "USING": true, "VALUES": true, "WHEN": true, "WHERE": true, "WITH": true,
+}
+
+func isReserved(keywords map[string]bool, identifier string) bool {
+	upper := strings.ToUpper(identifier)
+	return reserved[upper] || keywords[upper]
+}
@@ -0,0 +1,70 @@
+package dialect
+
+import (
+	"strings"
+	"sync"
+)
+
+var registry = &Registry{index: map[string]*Dialect{}}
+
+//Registry represents Dialect registry, keyed by database/sql driver name
+type Registry struct {
+	index map[string]*Dialect
+	mux   sync.RWMutex
+}
+
+//Register registers dialect for given drivers
+func (r *Registry) Register(dialect *Dialect, drivers ...string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, driver := range drivers {
+		r.index[strings.ToLower(driver)] = dialect
+	}
+}
+
+//Lookup returns Dialect for given driver, or ANSI dialect if driver was not registered
+func (r *Registry) Lookup(driver string) *Dialect {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	if dialect, ok := r.index[strings.ToLower(driver)]; ok {
+		return dialect
+	}
+
+	return ANSI
+}
+
+//Register registers dialect in the default registry
+func Register(dialect *Dialect, drivers ...string) {
+	registry.Register(dialect, drivers...)
+}
+
+//Lookup returns dialect from the default registry
+func Lookup(driver string) *Dialect {
+	return registry.Lookup(driver)
+}
+
+var (
+	//ANSI represents default dialect, used for unknown drivers, upsert is not supported as its syntax is driver specific
+	ANSI = &Dialect{Name: "ansi", Pagination: LimitOffset, Placeholder: QuestionMark, QuoteStart: `"`, QuoteEnd: `"`, Explain: "EXPLAIN", True: "TRUE", False: "FALSE"}
+	//MySQL represents MySQL dialect
+	MySQL = &Dialect{Name: "mysql", Pagination: LimitOffset, Placeholder: QuestionMark, QuoteStart: "`", QuoteEnd: "`", Explain: "EXPLAIN", Upsert: OnDuplicateKey, NullsFirst: true, True: "TRUE", False: "FALSE"}
+	//SQLite represents SQLite dialect
+	SQLite = &Dialect{Name: "sqlite", Pagination: LimitOffset, Placeholder: QuestionMark, QuoteStart: `"`, QuoteEnd: `"`, Explain: "EXPLAIN QUERY PLAN", Upsert: OnConflict, NullsFirst: true, True: "1", False: "0"}
+	//PostgreSQL represents PostgreSQL dialect
+	PostgreSQL = &Dialect{Name: "postgres", Pagination: LimitOffset, Placeholder: Dollar, QuoteStart: `"`, QuoteEnd: `"`, Explain: "EXPLAIN", Upsert: OnConflict, True: "TRUE", False: "FALSE"}
+	//BigQuery represents BigQuery dialect
+	BigQuery = &Dialect{Name: "bigquery", Pagination: LimitOffset, Placeholder: QuestionMark, QuoteStart: "`", QuoteEnd: "`", Upsert: Merge, NullsFirst: true, True: "TRUE", False: "FALSE"}
+	//SQLServer represents SQL Server dialect
+	SQLServer = &Dialect{Name: "sqlserver", Pagination: Top, Placeholder: AtP, QuoteStart: "[", QuoteEnd: "]", RequiresOrderBy: true, Upsert: Merge, NullsFirst: true, True: "1", False: "0"}
+	//Oracle represents Oracle dialect
+	Oracle = &Dialect{Name: "oracle", Pagination: RowNum, Placeholder: Colon, QuoteStart: `"`, QuoteEnd: `"`, Upsert: Merge, Dual: "DUAL", True: "1", False: "0"}
+)
+
+func init() {
+	Register(MySQL, "mysql")
+	Register(SQLite, "sqlite3", "sqlite")
+	Register(PostgreSQL, "postgres", "pgx", "pq")
+	Register(BigQuery, "bigquery")
+	Register(SQLServer, "sqlserver", "mssql")
+	Register(Oracle, "oracle", "godror", "oci8")
+}
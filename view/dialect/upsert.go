@@ -0,0 +1,176 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//OnConflict upsert uses INSERT ... ON CONFLICT (keys) DO UPDATE SET suffix
+	OnConflict = Upsert("ON CONFLICT")
+	//OnDuplicateKey upsert uses INSERT ... ON DUPLICATE KEY UPDATE suffix
+	OnDuplicateKey = Upsert("ON DUPLICATE KEY")
+	//Merge upsert uses MERGE statement
+	Merge = Upsert("MERGE")
+)
+
+//Upsert represents insert or update statement syntax
+type Upsert string
+
+//UpsertSQL returns statement inserting values into the table, or updating non key columns if record with the same keys already exists
+//values are SQL expressions, i.e. placeholders, matching columns
+func (d *Dialect) UpsertSQL(table string, columns, keys, values []string) (string, error) {
+	if len(columns) == 0 || len(columns) != len(values) {
+		return "", fmt.Errorf("invalid upsert %v columns, expected %v values but had %v", table, len(columns), len(values))
+	}
+
+	if len(keys) == 0 {
+		return "", fmt.Errorf("upsert %v conflict keys can't be empty", table)
+	}
+
+	keyIndex := map[string]bool{}
+	for _, key := range keys {
+		keyIndex[strings.ToLower(key)] = true
+	}
+
+	quoted := make([]string, len(columns))
+	var updatable []string
+	for i, column := range columns {
+		quoted[i] = d.Quote(column)
+		if !keyIndex[strings.ToLower(column)] {
+			updatable = append(updatable, quoted[i])
+		}
+	}
+
+	if len(keyIndex) != countKeys(columns, keyIndex) {
+		return "", fmt.Errorf("upsert %v columns have to include all conflict keys %v", table, keys)
+	}
+
+	quotedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		quotedKeys[i] = d.Quote(key)
+	}
+
+	switch d.Upsert {
+	case OnConflict:
+		return d.onConflict(table, quoted, quotedKeys, updatable, values), nil
+	case OnDuplicateKey:
+		return d.onDuplicateKey(table, quoted, quotedKeys, updatable, values), nil
+	case Merge:
+		return d.merge(table, quoted, quotedKeys, updatable, values), nil
+	}
+
+	return "", fmt.Errorf("upsert is not supported by %v dialect", d.Name)
+}
+
+func (d *Dialect) insert(sb *strings.Builder, table string, columns, values []string) {
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(table)
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(columns, ", "))
+	sb.WriteString(") VALUES (")
+	sb.WriteString(strings.Join(values, ", "))
+	sb.WriteString(")")
+}
+
+func (d *Dialect) onConflict(table string, columns, keys, updatable, values []string) string {
+	sb := &strings.Builder{}
+	d.insert(sb, table, columns, values)
+	sb.WriteString(" ON CONFLICT (")
+	sb.WriteString(strings.Join(keys, ", "))
+	sb.WriteString(")")
+	if len(updatable) == 0 {
+		sb.WriteString(" DO NOTHING")
+		return sb.String()
+	}
+
+	sb.WriteString(" DO UPDATE SET ")
+	for i, column := range updatable {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column + " = excluded." + column)
+	}
+
+	return sb.String()
+}
+
+func (d *Dialect) onDuplicateKey(table string, columns, keys, updatable, values []string) string {
+	sb := &strings.Builder{}
+	d.insert(sb, table, columns, values)
+	sb.WriteString(" ON DUPLICATE KEY UPDATE ")
+	if len(updatable) == 0 {
+		sb.WriteString(keys[0] + " = " + keys[0])
+		return sb.String()
+	}
+
+	for i, column := range updatable {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column + " = VALUES(" + column + ")")
+	}
+
+	return sb.String()
+}
+
+func (d *Dialect) merge(table string, columns, keys, updatable, values []string) string {
+	sb := &strings.Builder{}
+	sb.WriteString("MERGE INTO ")
+	sb.WriteString(table)
+	sb.WriteString(" t USING (SELECT ")
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(values[i] + " AS " + column)
+	}
+
+	if d.Dual != "" {
+		sb.WriteString(" FROM ")
+		sb.WriteString(d.Dual)
+	}
+
+	sb.WriteString(") s ON (")
+	for i, key := range keys {
+		if i != 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString("t." + key + " = s." + key)
+	}
+
+	sb.WriteString(")")
+	if len(updatable) > 0 {
+		sb.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		for i, column := range updatable {
+			if i != 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(column + " = s." + column)
+		}
+	}
+
+	sb.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	sb.WriteString(strings.Join(columns, ", "))
+	sb.WriteString(") VALUES (")
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("s." + column)
+	}
+
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func countKeys(columns []string, keyIndex map[string]bool) int {
+	count := 0
+	for _, column := range columns {
+		if keyIndex[strings.ToLower(column)] {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,122 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	EqualOperator        = "="
+	NotEqualOperator     = "!="
+	GreaterOperator      = ">"
+	GreaterEqualOperator = ">="
+	LowerOperator        = "<"
+	LowerEqualOperator   = "<="
+	LikeOperator         = "like"
+	NotLikeOperator      = "not like"
+	IlikeOperator        = "ilike"
+	NotIlikeOperator     = "not ilike"
+	InOperator           = "in"
+	NotInOperator        = "not in"
+	BetweenOperator      = "between"
+	IsNullOperator       = "is null"
+	IsNotNullOperator    = "is not null"
+)
+
+var filterOperators = []string{EqualOperator, NotEqualOperator, GreaterOperator, GreaterEqualOperator, LowerOperator, LowerEqualOperator,
+	LikeOperator, NotLikeOperator, IlikeOperator, NotIlikeOperator, InOperator, NotInOperator, BetweenOperator, IsNullOperator, IsNotNullOperator}
+
+//FilterRule restricts how filterable column can be used in the criteria
+type FilterRule struct {
+	Column    string   //column name, column with the rule is filterable
+	Operators []string `json:",omitempty"` //allowed operators, defaults to all operators
+	MaxInSize int      `json:",omitempty"` //maximum number of IN / NOT IN values, 0 means no limit
+	Requires  []string `json:",omitempty"` //columns that also have to be used in the criteria
+}
+
+func (v *View) initFilterRules() error {
+	for _, rule := range v.Selector.Constraints.FilterRules {
+		column, err := v._columns.Lookup(rule.Column)
+		if err != nil {
+			return fmt.Errorf("invalid view %v filter rule: %w", v.Name, err)
+		}
+
+		if column._filterRule != nil {
+			return fmt.Errorf("invalid view %v, duplicate filter rule for column %v", v.Name, column.Name)
+		}
+
+		column.SetFilterRule(rule)
+		rule.Column = column.Name
+	}
+
+	for _, rule := range v.Selector.Constraints.FilterRules {
+		if err := rule.init(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *FilterRule) init(aView *View) error {
+	for i, operator := range r.Operators {
+		r.Operators[i] = normalizeOperator(operator)
+		if !isFilterOperator(r.Operators[i]) {
+			return fmt.Errorf("invalid view %v filter rule, unsupported column %v operator %v", aView.Name, r.Column, operator)
+		}
+	}
+
+	if r.MaxInSize < 0 {
+		return fmt.Errorf("invalid view %v filter rule, column %v MaxInSize can't be negative", aView.Name, r.Column)
+	}
+
+	for i, colName := range r.Requires {
+		column, err := aView._columns.Lookup(colName)
+		if err != nil {
+			return fmt.Errorf("invalid view %v filter rule: %w", aView.Name, err)
+		}
+
+		if !column.Filterable {
+			return fmt.Errorf("invalid view %v filter rule, column %v requires not filterable column %v", aView.Name, r.Column, column.Name)
+		}
+
+		r.Requires[i] = column.Name
+	}
+
+	return nil
+}
+
+//CanUse indicates if operator can be used with the column
+func (r *FilterRule) CanUse(operator string) bool {
+	if len(r.Operators) == 0 {
+		return true
+	}
+
+	operator = normalizeOperator(operator)
+	for _, candidate := range r.Operators {
+		if candidate == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeOperator(operator string) string {
+	operator = strings.ToLower(strings.Join(strings.Fields(operator), " "))
+	if operator == "<>" {
+		return NotEqualOperator
+	}
+
+	return operator
+}
+
+func isFilterOperator(operator string) bool {
+	for _, candidate := range filterOperators {
+		if candidate == operator {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs"
+	"strings"
+	"sync"
+)
+
+type (
+	//Invalidator removes all entries of the tagged cache
+	Invalidator interface {
+		Purge(ctx context.Context) error
+	}
+
+	tagRegistry struct {
+		index map[string]*taggedCache
+		mutex sync.RWMutex
+	}
+
+	taggedCache struct {
+		tags        map[string]bool
+		invalidator Invalidator
+	}
+)
+
+var aTagRegistry = newTagRegistry()
+
+func newTagRegistry() *tagRegistry {
+	return &tagRegistry{index: map[string]*taggedCache{}}
+}
+
+//ResetTagRegistry removes all registered invalidators
+func ResetTagRegistry() {
+	aTagRegistry = newTagRegistry()
+}
+
+//RegisterInvalidator registers cache purged once any of the tags is invalidated, tags are case-insensitive
+//key identifies the cache storage, registering the same key again replaces previous invalidator
+func RegisterInvalidator(key string, invalidator Invalidator, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	tagged := &taggedCache{tags: map[string]bool{}, invalidator: invalidator}
+	for _, tag := range tags {
+		tagged.tags[strings.ToLower(tag)] = true
+	}
+
+	aTagRegistry.mutex.Lock()
+	aTagRegistry.index[key] = tagged
+	aTagRegistry.mutex.Unlock()
+}
+
+//InvalidateTags purges caches tagged with any of the tags, returns number of purged caches
+func InvalidateTags(ctx context.Context, tags ...string) (int, error) {
+	matched := map[string]Invalidator{}
+	aTagRegistry.mutex.RLock()
+	for key, tagged := range aTagRegistry.index {
+		for _, tag := range tags {
+			if tagged.tags[strings.ToLower(tag)] {
+				matched[key] = tagged.invalidator
+				break
+			}
+		}
+	}
+	aTagRegistry.mutex.RUnlock()
+
+	var err error
+	purged := 0
+	for key, invalidator := range matched {
+		if purgeErr := invalidator.Purge(ctx); purgeErr != nil {
+			err = fmt.Errorf("failed to purge %v cache: %w", key, purgeErr)
+			continue
+		}
+
+		purged++
+	}
+
+	return purged, err
+}
+
+//RelatedTags returns View and relations cache tags
+func (v *View) RelatedTags() []string {
+	var result []string
+	appendTags(v, map[string]bool{}, &result)
+	return result
+}
+
+func appendTags(aView *View, index map[string]bool, result *[]string) {
+	for _, tag := range aView.Tags {
+		if index[strings.ToLower(tag)] {
+			continue
+		}
+
+		index[strings.ToLower(tag)] = true
+		*result = append(*result, tag)
+	}
+
+	for _, relation := range aView.With {
+		appendTags(&relation.Of.View, index, result)
+	}
+}
+
+//purgeLocation removes afs location with all cached entries
+func purgeLocation(ctx context.Context, URL string) error {
+	fs := afs.New()
+	if ok, _ := fs.Exists(ctx, URL); !ok {
+		return nil
+	}
+
+	return fs.Delete(ctx, URL)
+}
@@ -0,0 +1,93 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testInvalidator struct {
+	purged int
+	err    error
+}
+
+func (t *testInvalidator) Purge(ctx context.Context) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	t.purged++
+	return nil
+}
+
+func TestInvalidateTags(t *testing.T) {
+	testcases := []struct {
+		description  string
+		tags         []string
+		expectPurged map[string]int
+		expectCount  int
+		expectErr    bool
+	}{
+		{
+			description:  "single tag",
+			tags:         []string{"EVENTS"},
+			expectPurged: map[string]int{"events": 1, "all": 1},
+			expectCount:  2,
+		},
+		{
+			description:  "case insensitive tags",
+			tags:         []string{"event_types", "Events"},
+			expectPurged: map[string]int{"events": 1, "types": 1, "all": 1},
+			expectCount:  3,
+		},
+		{
+			description:  "unknown tag",
+			tags:         []string{"USERS"},
+			expectPurged: map[string]int{},
+		},
+		{
+			description:  "purge error",
+			tags:         []string{"AUDIT"},
+			expectPurged: map[string]int{},
+			expectErr:    true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		ResetTagRegistry()
+		invalidators := map[string]*testInvalidator{
+			"events": {},
+			"types":  {},
+			"all":    {},
+			"audit":  {err: fmt.Errorf("purge error")},
+		}
+
+		RegisterInvalidator("events", &testInvalidator{}, "EVENTS")
+		RegisterInvalidator("events", invalidators["events"], "EVENTS")
+		RegisterInvalidator("types", invalidators["types"], "EVENT_TYPES")
+		RegisterInvalidator("all", invalidators["all"], "EVENTS", "EVENT_TYPES")
+		RegisterInvalidator("audit", invalidators["audit"], "AUDIT")
+		RegisterInvalidator("untagged", &testInvalidator{})
+
+		count, err := InvalidateTags(context.TODO(), testcase.tags...)
+		assert.Equal(t, testcase.expectErr, err != nil, testcase.description)
+		assert.Equal(t, testcase.expectCount, count, testcase.description)
+		for key, invalidator := range invalidators {
+			assert.Equal(t, testcase.expectPurged[key], invalidator.purged, testcase.description+" "+key)
+		}
+	}
+
+	ResetTagRegistry()
+}
+
+func TestView_RelatedTags(t *testing.T) {
+	aView := &View{
+		Tags: []string{"EVENTS"},
+		With: []*Relation{
+			{Of: &ReferenceView{View: View{Tags: []string{"events", "EVENT_TYPES"}}}},
+		},
+	}
+
+	assert.Equal(t, []string{"EVENTS", "EVENT_TYPES"}, aView.RelatedTags())
+}
@@ -0,0 +1,65 @@
+package view
+
+import (
+	"fmt"
+	"github.com/viant/datly/view/dialect"
+	"strings"
+)
+
+const (
+	VersionLock   = "version"
+	UpdatedAtLock = "updatedAt"
+)
+
+//OptimisticLock describes view column guarding executor updates against lost updates
+type OptimisticLock struct {
+	Column string
+	Kind   string
+}
+
+//Increment returns column assignment replacing expected lock value
+func (l *OptimisticLock) Increment(aDialect *dialect.Dialect) string {
+	column := aDialect.Quote(l.Column)
+	if l.Kind == UpdatedAtLock {
+		return column + " = CURRENT_TIMESTAMP"
+	}
+
+	return column + " = " + column + " + 1"
+}
+
+//OptimisticLock returns view optimistic lock or nil if none of the columns was configured with Lock hint
+func (v *View) OptimisticLock() *OptimisticLock {
+	return v._lock
+}
+
+func (v *View) initOptimisticLock() error {
+	for columnName, config := range v.ColumnsConfig {
+		if config.Lock == nil {
+			continue
+		}
+
+		kind, ok := lockKind(*config.Lock)
+		if !ok {
+			return fmt.Errorf("invalid view %v column %v Lock %v, supported: %v, %v", v.Name, columnName, *config.Lock, VersionLock, UpdatedAtLock)
+		}
+
+		if v._lock != nil && v._lock.Column != columnName {
+			return fmt.Errorf("invalid view %v, only one Lock column is allowed but found %v and %v", v.Name, v._lock.Column, columnName)
+		}
+
+		v._lock = &OptimisticLock{Column: columnName, Kind: kind}
+	}
+
+	return nil
+}
+
+func lockKind(kind string) (string, bool) {
+	switch strings.ToLower(strings.ReplaceAll(kind, "_", "")) {
+	case "", strings.ToLower(VersionLock):
+		return VersionLock, true
+	case strings.ToLower(UpdatedAtLock):
+		return UpdatedAtLock, true
+	}
+
+	return "", false
+}
@@ -1,8 +1,12 @@
 package view
 
 import (
+	"github.com/viant/datly/view/cache/kv"
+	"github.com/viant/datly/view/cache/mem"
 	"github.com/viant/gmetric"
+	"github.com/viant/gmetric/stat"
 	"reflect"
+	"strings"
 )
 
 type Metrics struct {
@@ -13,6 +17,38 @@ type Metrics struct {
 type metricsLocation struct {
 }
 
+//cacheMetricProvider maps cache lookup values into hit, miss, eviction and error counters
+type cacheMetricProvider struct{}
+
+func (p cacheMetricProvider) Keys() []string {
+	return []string{kv.HitKey, kv.MissKey, mem.EvictionKey, stat.ErrorKey}
+}
+
+func (p cacheMetricProvider) Map(value interface{}) int {
+	if _, ok := value.(error); ok {
+		return 3
+	}
+
+	switch value {
+	case kv.HitKey:
+		return 0
+	case kv.MissKey:
+		return 1
+	case mem.EvictionKey:
+		return 2
+	}
+
+	return -1
+}
+
+func (m *Metrics) operationName(name string) string {
+	if m.URIPart != "" {
+		name = m.URIPart + name
+	}
+
+	return strings.ReplaceAll(name, "/", ".")
+}
+
 func metricLocation() string {
 	return reflect.TypeOf(metricsLocation{}).PkgPath()
 }
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"fmt"
+	"github.com/viant/datly/shared"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+)
+
+func (v *View) initUpdatedAt() error {
+	if v.UpdatedAtColumn == "" {
+		return nil
+	}
+
+	column, err := v._columns.Lookup(v.UpdatedAtColumn)
+	if err != nil {
+		return fmt.Errorf("invalid view %v UpdatedAtColumn: %w", v.Name, err)
+	}
+
+	rType := shared.Elem(v.Schema.Type())
+	field := xunsafe.FieldByName(rType, column.FieldName())
+	if field == nil {
+		return fmt.Errorf("invalid view %v UpdatedAtColumn, not found field %v at type %v", v.Name, column.FieldName(), rType.String())
+	}
+
+	if field.Type != timeType && field.Type != timePtrType {
+		return fmt.Errorf("invalid view %v UpdatedAtColumn, column %v has to be of %v type but was %v", v.Name, column.Name, timeType.String(), field.Type.String())
+	}
+
+	v.UpdatedAtColumn = column.Name
+	v._updatedAt = field
+	return nil
+}
+
+//LastModified returns the most recent UpdatedAtColumn value across records in given slice pointer
+func (v *View) LastModified(slicePtr unsafe.Pointer) (time.Time, bool) {
+	var result time.Time
+	if v._updatedAt == nil {
+		return result, false
+	}
+
+	aSlice := v.Schema.Slice()
+	sliceLen := aSlice.Len(slicePtr)
+	for i := 0; i < sliceLen; i++ {
+		recordPtr := xunsafe.AsPointer(aSlice.ValuePointerAt(slicePtr, i))
+		if recordPtr == nil {
+			continue
+		}
+
+		var updatedAt *time.Time
+		switch actual := v._updatedAt.Value(recordPtr).(type) {
+		case time.Time:
+			updatedAt = &actual
+		case *time.Time:
+			updatedAt = actual
+		}
+
+		if updatedAt != nil && updatedAt.After(result) {
+			result = *updatedAt
+		}
+	}
+
+	return result, !result.IsZero()
+}
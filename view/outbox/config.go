@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	defaultTable           = "OUTBOX"
+	defaultMaxAttempts     = 3
+	defaultBatchSize       = 100
+	defaultRelayIntervalMs = 60000
+)
+
+var identifierExpr = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`)
+
+//Config configures events outbox, events are written to the Table within executor transaction
+//and delivered to the publisher after commit
+//Table has to define ID, TOPIC, PAYLOAD, CREATED_AT and ATTEMPTS columns
+type Config struct {
+	Table           string `json:",omitempty"` //defaults to OUTBOX
+	PublisherURL    string `json:",omitempty"` //registered publisher, mem://<name> in-memory publisher or afs location
+	MaxAttempts     int    `json:",omitempty"` //publish attempts before event is moved to DeadLetterURL, defaults to 3
+	DeadLetterURL   string `json:",omitempty"` //afs location of events exceeding MaxAttempts, events are discarded if empty
+	BatchSize       int    `json:",omitempty"` //max events delivered at once, defaults to 100
+	RelayIntervalMs int    `json:",omitempty"` //background delivery interval retrying failed events, defaults to 60000
+}
+
+//Init validates Config and sets defaults
+func (c *Config) Init() error {
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+
+	if !identifierExpr.MatchString(c.Table) {
+		return fmt.Errorf("invalid outbox table name %v", c.Table)
+	}
+
+	if c.PublisherURL == "" {
+		return fmt.Errorf("outbox %v publisher URL was empty", c.Table)
+	}
+
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+
+	if c.RelayIntervalMs <= 0 {
+		c.RelayIntervalMs = defaultRelayIntervalMs
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+//Event represents domain event written to the outbox table
+type Event struct {
+	ID        string
+	Topic     string
+	Payload   string //JSON encoded payload
+	CreatedAt time.Time
+	Attempts  int
+}
+
+//NewEvent creates Event with JSON encoded payload
+func NewEvent(topic string, payload interface{}) (*Event, error) {
+	if topic == "" {
+		return nil, fmt.Errorf("outbox event topic was empty")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v event payload: %w", topic, err)
+	}
+
+	return &Event{ID: uuid.New().String(), Topic: topic, Payload: string(data), CreatedAt: time.Now()}, nil
+}
+
+//InsertSQL returns statement writing event into the outbox table, followed by the statement args
+func (e *Event) InsertSQL(table string) (string, []interface{}) {
+	return "INSERT INTO " + table + " (ID, TOPIC, PAYLOAD, CREATED_AT, ATTEMPTS) VALUES (?, ?, ?, ?, ?)",
+		[]interface{}{e.ID, e.Topic, e.Payload, e.CreatedAt, e.Attempts}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	as "github.com/aerospike/aerospike-client-go"
 	"github.com/aerospike/aerospike-client-go/types"
+	"github.com/viant/datly/view/cache/mem"
+	"github.com/viant/datly/view/cache/redis"
 	"strconv"
 	"strings"
 	"sync"
@@ -41,6 +43,16 @@ type (
 		cancelFunc  func()
 		initialized bool
 	}
+
+	redisClientRegistry struct {
+		index map[string]*redis.Client
+		mutex sync.Mutex
+	}
+
+	memStoreRegistry struct {
+		index map[string]*mem.Store
+		mutex sync.Mutex
+	}
 )
 
 func ResetConnectionConfig() {
@@ -130,6 +142,8 @@ func (c *aerospikeClient) keepProbingIfNeeded(host string, port int) {
 
 var aDbPool = newPool()
 var aClientPool = newClientPool()
+var aRedisPool = newRedisPool()
+var aMemPool = newMemPool()
 
 func newClientPool() *aerospikeClientRegistry {
 	return &aerospikeClientRegistry{index: map[string]*aerospikeClient{}}
@@ -306,6 +320,60 @@ func ResetAerospikePool() {
 	aClientPool = newClientPool()
 }
 
+func ResetRedisPool() {
+	aRedisPool.mutex.Lock()
+	for _, aClient := range aRedisPool.index {
+		_ = aClient.Close()
+	}
+	aRedisPool.mutex.Unlock()
+	aRedisPool = newRedisPool()
+}
+
+func newRedisPool() *redisClientRegistry {
+	return &redisClientRegistry{index: map[string]*redis.Client{}}
+}
+
+//Client returns client shared by caches using the same redis URL and pool size
+func (r *redisClientRegistry) Client(URL string, poolSize int) (*redis.Client, error) {
+	aKey := strconv.Itoa(poolSize) + "#" + URL
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	client, ok := r.index[aKey]
+	if ok {
+		return client, nil
+	}
+
+	client, err := redis.NewClient(URL, poolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	r.index[aKey] = client
+	return client, nil
+}
+
+func ResetMemPool() {
+	aMemPool = newMemPool()
+}
+
+func newMemPool() *memStoreRegistry {
+	return &memStoreRegistry{index: map[string]*mem.Store{}}
+}
+
+//Store returns store shared by caches using the same key and limits, so that reloaded views purge the same store
+func (r *memStoreRegistry) Store(key string, maxEntries int, maxBytes int64, options ...interface{}) *mem.Store {
+	aKey := strconv.Itoa(maxEntries) + "#" + strconv.FormatInt(maxBytes, 10) + "#" + key
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	store, ok := r.index[aKey]
+	if !ok {
+		store = mem.NewStore(maxEntries, maxBytes, options...)
+		r.index[aKey] = store
+	}
+
+	return store
+}
+
 func newPool() *dbRegistry {
 	return &dbRegistry{index: map[string]*db{}}
 }
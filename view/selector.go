@@ -4,6 +4,7 @@ import (
 	"github.com/viant/datly/template/expand"
 	"github.com/viant/sqlx/io/read/cache"
 	"github.com/viant/toolbox/format"
+	"reflect"
 	"strings"
 	"sync"
 )
@@ -22,10 +23,14 @@ type (
 		Criteria       string        `json:",omitempty"`
 		Placeholders   []interface{} `json:",omitempty"`
 		Page           int
+		Cursor         []interface{} `json:",omitempty"`
+		GroupBy        []string      `json:",omitempty"`
+		Aggregates     []*Aggregate  `json:",omitempty"`
 
 		initialized  bool
 		_columnNames map[string]bool
 		result       *cache.ParmetrizedQuery
+		totalCount   int
 	}
 
 	ParamState struct {
@@ -46,6 +51,15 @@ func (s *Selector) CurrentPage() int {
 	return s.Page
 }
 
+func (s *Selector) CurrentTotalCount() int {
+	return s.totalCount
+}
+
+//SetTotalCount sets number of all records matching Selector criteria
+func (s *Selector) SetTotalCount(totalCount int) {
+	s.totalCount = totalCount
+}
+
 //Init initializes Selector
 func (s *Selector) Init() {
 	if s.initialized {
@@ -123,3 +137,44 @@ func (s *Selectors) Init() {
 		selector.Init()
 	}
 }
+
+//Clone returns Selectors copy, selectors can be modified without affecting the original ones, i.e. by concurrent read
+func (s *Selectors) Clone() *Selectors {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+	result := &Selectors{Index: make(map[string]*Selector, len(s.Index))}
+	for name, selector := range s.Index {
+		result.Index[name] = selector.Clone()
+	}
+
+	return result
+}
+
+//Clone returns Selector copy
+func (s *Selector) Clone() *Selector {
+	result := *s
+	result.Columns = append([]string(nil), s.Columns...)
+	result.Fields = append([]string(nil), s.Fields...)
+	result.Placeholders = append([]interface{}(nil), s.Placeholders...)
+	result.Cursor = append([]interface{}(nil), s.Cursor...)
+	result.GroupBy = append([]string(nil), s.GroupBy...)
+	result.Aggregates = append([]*Aggregate(nil), s.Aggregates...)
+	result.Parameters = ParamState{Values: cloneValue(s.Parameters.Values), Has: cloneValue(s.Parameters.Has)}
+	result._columnNames = make(map[string]bool, len(s._columnNames))
+	for name, has := range s._columnNames {
+		result._columnNames[name] = has
+	}
+
+	return &result
+}
+
+func cloneValue(value interface{}) interface{} {
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() != reflect.Ptr || rValue.IsNil() {
+		return value
+	}
+
+	result := reflect.New(rValue.Type().Elem())
+	result.Elem().Set(rValue.Elem())
+	return result.Interface()
+}
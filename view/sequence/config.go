@@ -0,0 +1,94 @@
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+const (
+	//AutoIncrement allocates IDs with sqlx next sequence, for auto-increment style databases, default
+	AutoIncrement = Strategy("autoincrement")
+	//DBSequence allocates IDs with database sequence, i.e. Postgres nextval
+	DBSequence = Strategy("sequence")
+	//RowID allocates IDs following the max table rowid, i.e. SQLite INTEGER PRIMARY KEY tables,
+	//supported only for single-writer deployments, where one datly instance writes to the table
+	RowID = Strategy("rowid")
+	//UUID assigns random UUID to string keys, i.e. for BigQuery tables
+	UUID = Strategy("uuid")
+	//ULID assigns lexicographically sortable ULID to string keys
+	ULID = Strategy("ulid")
+	//Snowflake assigns time ordered 64-bit snowflake IDs to integer keys
+	Snowflake = Strategy("snowflake")
+
+	//SnowflakeNodeBits represents number of snowflake ID bits used by node ID
+	SnowflakeNodeBits = 10
+	//MaxSnowflakeNode represents max snowflake node ID
+	MaxSnowflakeNode = int64(1)<<SnowflakeNodeBits - 1
+)
+
+var identifierExpr = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`)
+
+type (
+	//Strategy represents ID allocation strategy
+	Strategy string
+
+	//Config configures table ID allocation strategy
+	Config struct {
+		Table    string   `json:",omitempty"`
+		Strategy Strategy `json:",omitempty"`
+		Name     string   `json:",omitempty"` //database sequence name, defaults to <Table>_id_seq
+		Node     int64    `json:",omitempty"` //snowflake node ID, 0 - 1023
+		mux      sync.Mutex
+		rowID    int64 //last rowid reserved by RowID strategy
+	}
+)
+
+//Validate checks if Config is valid
+func (c *Config) Validate() error {
+	if c.Table == "" {
+		return fmt.Errorf("sequence table was empty")
+	}
+
+	switch c.Strategy {
+	case "", AutoIncrement, RowID, UUID, ULID:
+	case DBSequence:
+		if !IsIdentifier(c.SequenceName()) {
+			return fmt.Errorf("invalid %v sequence name %v", c.Table, c.SequenceName())
+		}
+	case Snowflake:
+		if c.Node < 0 || c.Node > MaxSnowflakeNode {
+			return fmt.Errorf("invalid %v snowflake node %v, expected 0 - %v", c.Table, c.Node, MaxSnowflakeNode)
+		}
+	default:
+		return fmt.Errorf("unsupported %v sequence strategy %v, supported: %v, %v, %v, %v, %v, %v", c.Table, c.Strategy, AutoIncrement, DBSequence, RowID, UUID, ULID, Snowflake)
+	}
+
+	return nil
+}
+
+//SequenceName returns database sequence name
+func (c *Config) SequenceName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return c.Table + "_id_seq"
+}
+
+//ReserveRowIDs reserves count rowids following maxID, or the last reserved rowid if greater, and returns the first one
+func (c *Config) ReserveRowIDs(maxID int64, count int) int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.rowID > maxID {
+		maxID = c.rowID
+	}
+
+	c.rowID = maxID + int64(count)
+	return maxID + 1
+}
+
+//IsIdentifier returns true if name is a plain, optionally schema qualified, database identifier
+func IsIdentifier(name string) bool {
+	return identifierExpr.MatchString(name)
+}
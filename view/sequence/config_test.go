@@ -0,0 +1,43 @@
+package sequence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	var testCases = []struct {
+		description string
+		config      *Config
+		expectErr   bool
+	}{
+		{description: "default strategy", config: &Config{Table: "EVENTS"}},
+		{description: "postgres sequence", config: &Config{Table: "events", Strategy: DBSequence, Name: "public.events_seq"}},
+		{description: "invalid sequence name", config: &Config{Table: "events", Strategy: DBSequence, Name: "x'); DROP TABLE events;--"}, expectErr: true},
+		{description: "invalid snowflake node", config: &Config{Table: "events", Strategy: Snowflake, Node: 1024}, expectErr: true},
+		{description: "unsupported strategy", config: &Config{Table: "events", Strategy: "random"}, expectErr: true},
+		{description: "missing table", config: &Config{Strategy: UUID}, expectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectErr, testCase.config.Validate() != nil, testCase.description)
+	}
+}
+
+func TestConfig_ReserveRowIDs(t *testing.T) {
+	var testCases = []struct {
+		description string
+		maxID       int64
+		count       int
+		expect      int64
+	}{
+		{description: "first reservation", maxID: 10, count: 2, expect: 11},
+		{description: "not inserted yet reservation", maxID: 10, count: 1, expect: 13},
+		{description: "max rowid greater than reserved", maxID: 20, count: 1, expect: 21},
+	}
+
+	config := &Config{Table: "EVENTS", Strategy: RowID}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, config.ReserveRowIDs(testCase.maxID, testCase.count), testCase.description)
+	}
+}
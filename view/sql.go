@@ -6,6 +6,7 @@ import (
 	"github.com/viant/datly/converter"
 	"github.com/viant/datly/reader/metadata"
 	"github.com/viant/datly/template/expand"
+	"github.com/viant/datly/view/dialect"
 	"github.com/viant/datly/view/keywords"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
@@ -207,11 +208,12 @@ func detectColumnsSQL(evaluation *TemplateEvaluation, v *View) (string, []interf
 	sb.WriteString(source)
 	sb.WriteString(" ")
 	sb.WriteString(v.Alias)
-	sb.WriteString(" WHERE 1=0")
+	sb.WriteString(" WHERE ")
+	sb.WriteString(v.Dialect().Predicate(false))
 
 	SQL := sb.String()
 	if source != v.Name && source != v.Table {
-		SQL = ExpandWithFalseCondition(source)
+		SQL = ExpandWithFalseCondition(source, v.Dialect())
 	}
 
 	var placeholders []interface{}
@@ -237,11 +239,12 @@ func NewMockSanitizer() *expand.SQLCriteria {
 	}
 }
 
-func ExpandWithFalseCondition(source string) string {
+func ExpandWithFalseCondition(source string, aDialect *dialect.Dialect) string {
 	discover := metadata.EnrichWithDiscover(source, false)
+	condition := aDialect.Predicate(false)
 	replacement := rdata.Map{}
-	replacement.Put(keywords.AndCriteria[1:], "\n\n AND 1=0 ")
-	replacement.Put(keywords.WhereCriteria[1:], "\n\n WHERE 1=0 ")
+	replacement.Put(keywords.AndCriteria[1:], "\n\n AND "+condition+" ")
+	replacement.Put(keywords.WhereCriteria[1:], "\n\n WHERE "+condition+" ")
 	SQL := replacement.ExpandAsText(discover)
 	return SQL
 }
@@ -18,7 +18,7 @@ func TestDetectColumnsSQL(t *testing.T) {
 			},
 			sql: `SELECT * FROM FOOS 
 
- WHERE 1=0 `,
+ WHERE TRUE = FALSE `,
 		},
 		{
 			description: `Criteria`,
@@ -28,7 +28,7 @@ func TestDetectColumnsSQL(t *testing.T) {
 			},
 			sql: `SELECT * FROM FOOS 
 
- WHERE 1=0 `,
+ WHERE TRUE = FALSE `,
 		},
 		{
 			description: `Criteria with where`,
@@ -38,7 +38,7 @@ func TestDetectColumnsSQL(t *testing.T) {
 			},
 			sql: `SELECT * FROM FOOS  WHERE id = 10  
 
- AND 1=0 `,
+ AND TRUE = FALSE `,
 		},
 		{
 			description: `Criteria with where`,
@@ -172,7 +172,7 @@ func (m *TemplateMeta) prepareSQL(owner *Template) (string, []interface{}, error
 		return "", nil, err
 	}
 
-	viewParam.NonWindowSQL = ExpandWithFalseCondition(state.Buffer.String())
+	viewParam.NonWindowSQL = ExpandWithFalseCondition(state.Buffer.String(), owner._view.Dialect())
 	viewParam.Args = sanitizer.ParamsGroup
 	return m.Evaluate(selectorValues, selectorPresence, viewParam)
 }
@@ -0,0 +1,90 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//TxOptions configures transaction used by the executor
+type TxOptions struct {
+	Isolation          string `json:",omitempty"` //isolation level, i.e. read_committed, repeatable_read, serializable, defaults to driver default
+	ReadOnly           bool   `json:",omitempty"`
+	TimeoutMs          int    `json:",omitempty"` //whole transaction timeout, 0 means no timeout
+	StatementTimeoutMs int    `json:",omitempty"` //single statement timeout, 0 means no timeout
+
+	_isolation sql.IsolationLevel
+}
+
+//Init validates and initializes TxOptions
+func (o *TxOptions) Init() error {
+	if o.TimeoutMs < 0 || o.StatementTimeoutMs < 0 {
+		return fmt.Errorf("transaction timeout can't be negative")
+	}
+
+	if o.Isolation == "" {
+		return nil
+	}
+
+	isolation, ok := isolationLevel(o.Isolation)
+	if !ok {
+		return fmt.Errorf("unsupported transaction isolation level %v", o.Isolation)
+	}
+
+	o._isolation = isolation
+	return nil
+}
+
+//Options returns sql.TxOptions
+func (o *TxOptions) Options() *sql.TxOptions {
+	if o == nil {
+		return nil
+	}
+
+	return &sql.TxOptions{Isolation: o._isolation, ReadOnly: o.ReadOnly}
+}
+
+//TxContext returns context with transaction deadline if TimeoutMs was specified
+func (o *TxOptions) TxContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o == nil {
+		return context.WithCancel(ctx)
+	}
+
+	return withTimeout(ctx, o.TimeoutMs)
+}
+
+//StatementContext returns context with statement deadline if StatementTimeoutMs was specified
+func (o *TxOptions) StatementContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o == nil {
+		return context.WithCancel(ctx)
+	}
+
+	return withTimeout(ctx, o.StatementTimeoutMs)
+}
+
+func withTimeout(ctx context.Context, timeoutMs int) (context.Context, context.CancelFunc) {
+	if timeoutMs == 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+}
+
+func isolationLevel(name string) (sql.IsolationLevel, bool) {
+	normalized := normalizeIsolation(name)
+	for level := sql.LevelDefault; level <= sql.LevelLinearizable; level++ {
+		if normalizeIsolation(level.String()) == normalized {
+			return level, true
+		}
+	}
+
+	return sql.LevelDefault, false
+}
+
+func normalizeIsolation(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "_", "")
+	return strings.ReplaceAll(name, " ", "")
+}
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTxOptions_Init(t *testing.T) {
+	testcases := []struct {
+		description string
+		options     *TxOptions
+		expect      *sql.TxOptions
+		expectErr   bool
+	}{
+		{
+			description: "default isolation",
+			options:     &TxOptions{ReadOnly: true},
+			expect:      &sql.TxOptions{ReadOnly: true},
+		},
+		{
+			description: "snake case isolation",
+			options:     &TxOptions{Isolation: "read_committed"},
+			expect:      &sql.TxOptions{Isolation: sql.LevelReadCommitted},
+		},
+		{
+			description: "sql isolation name",
+			options:     &TxOptions{Isolation: "Serializable"},
+			expect:      &sql.TxOptions{Isolation: sql.LevelSerializable},
+		},
+		{
+			description: "unsupported isolation",
+			options:     &TxOptions{Isolation: "eventual"},
+			expectErr:   true,
+		},
+		{
+			description: "negative timeout",
+			options:     &TxOptions{TimeoutMs: -1},
+			expectErr:   true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		err := testcase.options.Init()
+		if testcase.expectErr {
+			assert.NotNil(t, err, testcase.description)
+			continue
+		}
+
+		if !assert.Nil(t, err, testcase.description) {
+			continue
+		}
+
+		assert.Equal(t, testcase.expect, testcase.options.Options(), testcase.description)
+	}
+}
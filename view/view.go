@@ -8,7 +8,10 @@ import (
 	"github.com/viant/datly/router/marshal"
 	"github.com/viant/datly/shared"
 	"github.com/viant/datly/template/expand"
+	"github.com/viant/datly/view/dialect"
 	"github.com/viant/datly/view/keywords"
+	"github.com/viant/datly/view/outbox"
+	"github.com/viant/datly/view/sequence"
 	"github.com/viant/gmetric/provider"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/option"
@@ -73,10 +76,18 @@ type (
 		SelfReference *SelfReference           `json:",omitempty"`
 		Namespaces    []*Namespace             `json:",omitempty"`
 
+		UpdatedAtColumn string             `json:",omitempty"`
+		TxOptions       *TxOptions         `json:",omitempty"`
+		Sequences       []*sequence.Config `json:",omitempty"` //tables ID allocation strategies used by the executor sequencer
+		Outbox          *outbox.Config     `json:",omitempty"` //events outbox delivered after executor commit
+		Tags            []string           `json:",omitempty"` //cache tags, i.e. table names, executor View purges caches tagged with its tags after commit
+
 		initialized  bool
 		newCollector newCollectorFn
 
-		codec *columnsCodec
+		codec      *columnsCodec
+		_updatedAt *xunsafe.Field
+		_lock      *OptimisticLock
 	}
 
 	SelfReference struct {
@@ -97,9 +108,16 @@ type (
 		Offset      bool
 		Projection  bool //enables columns projection from client (default ${NS}_fields= query param)
 		Filterable  []string
-		SQLMethods  []*Method `json:",omitempty"`
+		FilterRules []*FilterRule `json:",omitempty"` //per column operators, IN list size and companion filter rules
+		SQLMethods  []*Method     `json:",omitempty"`
 		_sqlMethods map[string]*Method
 		Page        *bool
+		Cursor      bool     `json:",omitempty"` //enables keyset pagination (default ${NS}_cursor= query param)
+		CursorKey   string   `json:",omitempty"` //cursor signing key, defaults to DATLY_CURSOR_KEY env variable
+		PrimaryKey  []string `json:",omitempty"` //cursor order tiebreaker columns, defaults to View.Table primary key
+		_cursorKey  []byte
+		Count       bool         `json:",omitempty"` //enables total count of records matching criteria
+		Aggregation *Aggregation `json:",omitempty"`
 	}
 
 	Batch struct {
@@ -161,7 +179,7 @@ func (c *Constraints) init(resource *Resource) error {
 		}
 	}
 
-	return nil
+	return c.initCursorKey()
 }
 
 func (c *Constraints) IsPageEnabled() bool {
@@ -354,12 +372,52 @@ func (v *View) initView(ctx context.Context, resource *Resource, transforms mars
 		return err
 	}
 
+	if err = v.ensureCursorPrimaryKey(ctx); err != nil {
+		return err
+	}
+
 	if err = v.markColumnsAsFilterable(); err != nil {
 		return err
 	}
 
+	if err = v.initFilterRules(); err != nil {
+		return err
+	}
+
+	if aggregation := v.Selector.Constraints.Aggregation; aggregation != nil {
+		if err = aggregation.init(v); err != nil {
+			return err
+		}
+	}
+
 	v.updateColumnTypes()
 
+	if err = v.initUpdatedAt(); err != nil {
+		return err
+	}
+
+	if err = v.initOptimisticLock(); err != nil {
+		return err
+	}
+
+	if v.TxOptions != nil {
+		if err = v.TxOptions.Init(); err != nil {
+			return fmt.Errorf("invalid view %v: %w", v.Name, err)
+		}
+	}
+
+	for _, aSequence := range v.Sequences {
+		if err = aSequence.Validate(); err != nil {
+			return fmt.Errorf("invalid view %v: %w", v.Name, err)
+		}
+	}
+
+	if v.Outbox != nil {
+		if err = v.Outbox.Init(); err != nil {
+			return fmt.Errorf("invalid view %v: %w", v.Name, err)
+		}
+	}
+
 	if err = v.initTemplate(ctx, resource); err != nil {
 		return err
 	}
@@ -404,12 +462,7 @@ func (v *View) ensureCounter(resource *Resource) {
 	}
 	var counter logger.Counter
 	if metric := resource.Metrics; metric != nil {
-		name := v.Name
-		if metric.URIPart != "" {
-			name = metric.URIPart + name
-		}
-		name = strings.ReplaceAll(name, "/", ".")
-
+		name := metric.operationName(v.Name)
 		cnt := metric.Service.LookupOperation(name)
 		if cnt == nil {
 			counter = metric.Service.MultiOperationCounter(metricLocation(), name, name+" performance", time.Millisecond, time.Minute, 2, provider.NewBasic())
@@ -584,6 +637,25 @@ func (v *View) Db() (*sql.DB, error) {
 	return v.Connector.DB()
 }
 
+//Dialect returns SQL dialect of the View connector
+func (v *View) Dialect() *dialect.Dialect {
+	if v.Connector == nil {
+		return dialect.ANSI
+	}
+
+	return v.Connector.Dialect()
+}
+
+//SequenceConfigs returns tables ID allocation strategies
+func (v *View) SequenceConfigs() []*sequence.Config {
+	return v.Sequences
+}
+
+//OutboxConfig returns events outbox configuration
+func (v *View) OutboxConfig() *outbox.Config {
+	return v.Outbox
+}
+
 func (v *View) exclude(columns []io.Column) []io.Column {
 	if len(v.Exclude) == 0 {
 		return columns
@@ -677,6 +749,24 @@ func (v *View) inherit(view *View) error {
 		v.SelfReference = view.SelfReference
 	}
 
+	v.UpdatedAtColumn = FirstNotEmpty(v.UpdatedAtColumn, view.UpdatedAtColumn)
+
+	if v.TxOptions == nil {
+		v.TxOptions = view.TxOptions
+	}
+
+	if v.Sequences == nil {
+		v.Sequences = view.Sequences
+	}
+
+	if v.Outbox == nil {
+		v.Outbox = view.Outbox
+	}
+
+	if v.Tags == nil {
+		v.Tags = view.Tags
+	}
+
 	return nil
 }
 
@@ -782,6 +872,21 @@ func (v *View) CanUseSelectorOffset() bool {
 	return v.Selector.Constraints.Offset
 }
 
+// CanUseSelectorCursor indicates if Selector.Cursor can be used
+func (v *View) CanUseSelectorCursor() bool {
+	return v.Selector.Constraints.Cursor
+}
+
+// CanUseSelectorAggregation indicates if Selector.GroupBy and Selector.Aggregates can be used
+func (v *View) CanUseSelectorAggregation() bool {
+	return v.Selector.Constraints.Aggregation != nil
+}
+
+// IsCountEnabled indicates if total count of records matching criteria should be computed
+func (v *View) IsCountEnabled() bool {
+	return v.Selector.Constraints.Count
+}
+
 // CanUseSelectorProjection indicates if Selector.Fields can be used
 func (v *View) CanUseSelectorProjection() bool {
 	return v.Selector.Constraints.Projection
@@ -13,17 +13,19 @@ import (
 
 type (
 	matchersCollector struct {
-		size     int
-		matchers []*cache.ParmetrizedQuery
-		mux      sync.Mutex
-		builder  *reader.Builder
-		view     *view.View
+		size       int
+		matchers   []*cache.ParmetrizedQuery
+		mux        sync.Mutex
+		builder    *reader.Builder
+		view       *view.View
+		watermarks *Watermarks
 	}
 
 	warmupEntry struct {
-		matcher *cache.ParmetrizedQuery
-		view    *view.View
-		column  string
+		matcher   *cache.ParmetrizedQuery
+		view      *view.View
+		column    string
+		watermark *caseWatermark
 	}
 
 	warmupEntryFn func() (*warmupEntry, error)
@@ -61,16 +63,18 @@ func (c *matchersCollector) populateCacheCases(ctx context.Context, collector ch
 }
 
 func (c *matchersCollector) populateChan(aView *view.View, aChan chan warmupEntryFn, cacheInput *view.CacheInput) {
-	c.createIndexWarmupEntry(aView, aChan, cacheInput)
+	matcher, err := c.builder.CacheSQL(c.view, cacheInput.Selector)
+	watermark := c.watermarks.caseWatermark(aView, matcher)
+	c.createIndexWarmupEntry(aView, aChan, cacheInput, matcher, err, watermark)
 
 	if !cacheInput.IndexMeta {
 		return
 	}
 
-	c.createMetaWarmupEntry(aView, aChan, cacheInput)
+	c.createMetaWarmupEntry(aView, aChan, cacheInput, watermark)
 }
 
-func (c *matchersCollector) createMetaWarmupEntry(aView *view.View, aChan chan warmupEntryFn, input *view.CacheInput) {
+func (c *matchersCollector) createMetaWarmupEntry(aView *view.View, aChan chan warmupEntryFn, input *view.CacheInput, watermark *caseWatermark) {
 	cacheIndex, err := c.builder.CacheMetaSQL(aView, input.Selector, nil, nil, nil)
 	if err != nil {
 		aChan <- func() (*warmupEntry, error) {
@@ -81,35 +85,37 @@ func (c *matchersCollector) createMetaWarmupEntry(aView *view.View, aChan chan w
 
 	aChan <- func() (*warmupEntry, error) {
 		return &warmupEntry{
-			matcher: cacheIndex,
-			view:    aView,
-			column:  input.MetaColumn,
+			matcher:   cacheIndex,
+			view:      aView,
+			column:    input.MetaColumn,
+			watermark: watermark,
 		}, nil
 	}
 }
 
-func (c *matchersCollector) createIndexWarmupEntry(aView *view.View, aChan chan warmupEntryFn, cacheInput *view.CacheInput) {
-	build, err := c.builder.CacheSQL(c.view, cacheInput.Selector)
+func (c *matchersCollector) createIndexWarmupEntry(aView *view.View, aChan chan warmupEntryFn, cacheInput *view.CacheInput, build *cache.ParmetrizedQuery, err error, watermark *caseWatermark) {
 	aChan <- func() (*warmupEntry, error) {
 		if err != nil {
 			return nil, err
 		}
 
 		return &warmupEntry{
-			matcher: build,
-			view:    aView,
-			column:  cacheInput.Column,
+			matcher:   build,
+			view:      aView,
+			column:    cacheInput.Column,
+			watermark: watermark,
 		}, err
 	}
 }
 
-func populateCollector(ctx context.Context, aView *view.View, builder *reader.Builder, collector chan warmupEntryFn, notifier chan notifierFn) {
+func populateCollector(ctx context.Context, aView *view.View, builder *reader.Builder, watermarks *Watermarks, collector chan warmupEntryFn, notifier chan notifierFn) {
 	(&matchersCollector{
-		size:     0,
-		matchers: nil,
-		view:     aView,
-		builder:  builder,
-		mux:      sync.Mutex{},
+		size:       0,
+		matchers:   nil,
+		view:       aView,
+		builder:    builder,
+		mux:        sync.Mutex{},
+		watermarks: watermarks,
 	}).populate(ctx, collector, notifier)
 }
 
@@ -132,6 +138,13 @@ func readWithErr(ctx context.Context, entry *warmupEntry) (int, error) {
 		return 0, err
 	}
 
+	if entry.watermark != nil {
+		refresh, err := entry.watermark.shouldRefresh(ctx, db)
+		if err != nil || !refresh {
+			return 0, err
+		}
+	}
+
 	service, err := entry.view.Cache.Service()
 	if err != nil {
 		return 0, err
@@ -140,6 +153,10 @@ func readWithErr(ctx context.Context, entry *warmupEntry) (int, error) {
 	matcher := entry.matcher
 	indexed, err := service.IndexBy(ctx, db, entry.column, matcher.SQL, matcher.Args)
 	if err != nil {
+		if entry.watermark != nil {
+			entry.watermark.watermarks.reset(entry.watermark.key)
+		}
+
 		return indexed, fmt.Errorf("failed to index: %w, %v", err, matcher.SQL)
 	}
 
@@ -155,6 +172,16 @@ func DB(entry *warmupEntry) (*sql.DB, error) {
 }
 
 func PopulateCache(views []*view.View) (int, error) {
+	return populateCache(context.Background(), views, nil)
+}
+
+//Refresh re-runs views warmup, with watermarks cases of views with Schedule.WatermarkColumn are refreshed only
+//if their source rows changed, or entries are about to expire
+func Refresh(ctx context.Context, views []*view.View, watermarks *Watermarks) (int, error) {
+	return populateCache(ctx, views, watermarks)
+}
+
+func populateCache(ctx context.Context, views []*view.View, watermarks *Watermarks) (int, error) {
 	viewsWithCache := FilterCacheViews(views)
 
 	if len(viewsWithCache) == 0 {
@@ -163,11 +190,10 @@ func PopulateCache(views []*view.View) (int, error) {
 
 	collector := make(chan warmupEntryFn)
 	notifier := make(chan notifierFn)
-	ctx := context.Background()
 
 	builder := reader.NewBuilder()
 	for i := range viewsWithCache {
-		populateCollector(ctx, viewsWithCache[i], builder, collector, notifier)
+		populateCollector(ctx, viewsWithCache[i], builder, watermarks, collector, notifier)
 	}
 
 	counter := 0
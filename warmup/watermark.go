@@ -0,0 +1,112 @@
+package warmup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/datly/view"
+	"github.com/viant/sqlx/io/read/cache"
+	"sync"
+	"time"
+)
+
+type (
+	//Watermarks tracks warmup cases source rows state between scheduled runs, used by incremental warmup
+	Watermarks struct {
+		index map[string]*watermark
+		mux   sync.Mutex
+		Now   func() time.Time
+	}
+
+	watermark struct {
+		signature string
+		refreshed time.Time
+	}
+
+	//caseWatermark decides once whether warmup case index and meta entries need to be refreshed
+	caseWatermark struct {
+		key          string
+		column       string
+		refreshAfter time.Duration
+		matcher      *cache.ParmetrizedQuery
+		watermarks   *Watermarks
+		once         sync.Once
+		refresh      bool
+		err          error
+	}
+)
+
+//NewWatermarks creates Watermarks
+func NewWatermarks() *Watermarks {
+	return &Watermarks{index: map[string]*watermark{}, Now: time.Now}
+}
+
+//caseWatermark returns case watermark, or nil if View warmup is not incremental
+func (w *Watermarks) caseWatermark(aView *view.View, matcher *cache.ParmetrizedQuery) *caseWatermark {
+	if w == nil || matcher == nil {
+		return nil
+	}
+
+	schedule := aView.Cache.Warmup.Schedule
+	if schedule == nil || schedule.WatermarkColumn == "" {
+		return nil
+	}
+
+	return &caseWatermark{
+		key:          fmt.Sprintf("%v|%v|%v|%v", aView.Name, aView.Cache.Location, matcher.SQL, matcher.Args),
+		column:       schedule.WatermarkColumn,
+		refreshAfter: aView.Cache.WarmupRefreshAfter(),
+		matcher:      matcher,
+		watermarks:   w,
+	}
+}
+
+//update records case signature, returns true if case has changed or was refreshed more than refreshAfter ago
+func (w *Watermarks) update(key, signature string, refreshAfter time.Duration) bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	now := w.Now()
+	prev, ok := w.index[key]
+	if ok && prev.signature == signature && now.Sub(prev.refreshed) < refreshAfter {
+		return false
+	}
+
+	w.index[key] = &watermark{signature: signature, refreshed: now}
+	return true
+}
+
+//reset removes case state, so that next run refreshes it
+func (w *Watermarks) reset(key string) {
+	w.mux.Lock()
+	delete(w.index, key)
+	w.mux.Unlock()
+}
+
+func (w *caseWatermark) shouldRefresh(ctx context.Context, db *sql.DB) (bool, error) {
+	w.once.Do(func() {
+		var signature string
+		if signature, w.err = querySignature(ctx, db, w.column, w.matcher); w.err != nil {
+			return
+		}
+
+		w.refresh = w.watermarks.update(w.key, signature, w.refreshAfter)
+	})
+
+	return w.refresh, w.err
+}
+
+//querySignature returns case rows max watermark and count, count detects removed rows
+func querySignature(ctx context.Context, db *sql.DB, column string, matcher *cache.ParmetrizedQuery) (string, error) {
+	SQL := "SELECT MAX(t." + column + "), COUNT(1) FROM (" + matcher.SQL + ") t"
+	var maxValue, count interface{}
+	if err := db.QueryRowContext(ctx, SQL, matcher.Args...).Scan(&maxValue, &count); err != nil {
+		return "", fmt.Errorf("failed to read warmup watermark: %w, %v", err, SQL)
+	}
+
+	if bytes, ok := maxValue.([]byte); ok {
+		maxValue = string(bytes)
+	}
+
+	return fmt.Sprintf("%v/%v", maxValue, count), nil
+}
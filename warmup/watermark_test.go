@@ -0,0 +1,96 @@
+package warmup
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read/cache"
+	"testing"
+	"time"
+)
+
+func TestCaseWatermark_ShouldRefresh(t *testing.T) {
+	testcases := []struct {
+		description   string
+		SQL           string
+		elapsed       time.Duration
+		expectRefresh bool
+		expectErr     bool
+	}{
+		{
+			description:   "first run",
+			expectRefresh: true,
+		},
+		{
+			description: "unchanged rows",
+			elapsed:     time.Minute,
+		},
+		{
+			description:   "updated row",
+			SQL:           "UPDATE EVENTS SET UPDATED = '2022-01-03' WHERE ID = 1",
+			elapsed:       time.Minute,
+			expectRefresh: true,
+		},
+		{
+			description:   "removed row",
+			SQL:           "DELETE FROM EVENTS WHERE ID = 2",
+			elapsed:       time.Minute,
+			expectRefresh: true,
+		},
+		{
+			description:   "unchanged rows about to expire",
+			elapsed:       10 * time.Minute,
+			expectRefresh: true,
+		},
+		{
+			description: "invalid watermark column",
+			SQL:         "ALTER TABLE EVENTS RENAME COLUMN UPDATED TO MODIFIED",
+			expectErr:   true,
+		},
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	for _, SQL := range []string{
+		"CREATE TABLE EVENTS(ID INTEGER PRIMARY KEY, UPDATED TEXT)",
+		"INSERT INTO EVENTS(ID, UPDATED) VALUES (1, '2022-01-01'), (2, '2022-01-02')",
+	} {
+		_, err = db.Exec(SQL)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	now := time.Now()
+	watermarks := NewWatermarks()
+	watermarks.Now = func() time.Time {
+		return now
+	}
+
+	matcher := &cache.ParmetrizedQuery{SQL: "SELECT ID, UPDATED FROM EVENTS WHERE ID > ?", Args: []interface{}{0}}
+	for _, testcase := range testcases {
+		if testcase.SQL != "" {
+			_, err = db.Exec(testcase.SQL)
+			assert.Nil(t, err, testcase.description)
+		}
+
+		now = now.Add(testcase.elapsed)
+		watermark := &caseWatermark{
+			key:          "events",
+			column:       "UPDATED",
+			refreshAfter: 5 * time.Minute,
+			matcher:      matcher,
+			watermarks:   watermarks,
+		}
+
+		refresh, err := watermark.shouldRefresh(context.TODO(), db)
+		assert.Equal(t, testcase.expectErr, err != nil, testcase.description)
+		assert.Equal(t, testcase.expectRefresh, refresh, testcase.description)
+	}
+}